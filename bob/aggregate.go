@@ -35,12 +35,12 @@ func (b *B) PrintVersionCompatibility(bobfile *bobfile.Bobfile) {
 			bobletVersion, _ := version.NewVersion(boblet.Version)
 
 			if binVersion.Core().Segments64()[0] != bobletVersion.Core().Segments64()[0] {
-				fmt.Println(aurora.Red(fmt.Sprintf("Warning: major version mismatch: Your bobfile's major version (%s, '%s') is different from the CLI version (%s). This might lead to unexpected errors.", boblet.Version, boblet.Dir(), binVersion)).String())
+				boblog.Log.Info(aurora.Red(fmt.Sprintf("Warning: major version mismatch: Your bobfile's major version (%s, '%s') is different from the CLI version (%s). This might lead to unexpected errors.", boblet.Version, boblet.Dir(), binVersion)).String())
 				continue
 			}
 
 			if binVersion.LessThan(bobletVersion) {
-				fmt.Println(aurora.Red(fmt.Sprintf("Warning: possible version incompatibility: Your bobfile's version (%s, '%s') is higher than the CLI version (%s). Some features might not work as expected.", boblet.Version, boblet.Dir(), binVersion)).String())
+				boblog.Log.Info(aurora.Red(fmt.Sprintf("Warning: possible version incompatibility: Your bobfile's version (%s, '%s') is higher than the CLI version (%s). Some features might not work as expected.", boblet.Version, boblet.Dir(), binVersion)).String())
 				continue
 			}
 		}
@@ -92,6 +92,8 @@ func (b *B) AggregateSparse(omitRunTasks ...bool) (aggregate *bobfile.Bobfile, e
 	aggregate, err = b.addBuildTasksToAggregate(aggregate, bobs, nil)
 	errz.Fatal(err)
 
+	b.evaluateConditions(aggregate)
+
 	if addRunTasks {
 		aggregate = b.addRunTasksToAggregate(aggregate, bobs)
 	}
@@ -127,7 +129,10 @@ func (b *B) Aggregate() (aggregate *bobfile.Bobfile, err error) {
 
 	for _, boblet := range append(bobs, aggregate) {
 		for key, task := range boblet.BTasks {
-			task.SetEnv(envutil.Merge(boblet.Vars(), b.env))
+			// task variables override bobfile variables,
+			// both can be overridden by `--env`.
+			env := envutil.Merge(boblet.Vars(), task.TaskEnv())
+			task.SetEnv(envutil.Merge(env, b.env))
 			boblet.BTasks[key] = task
 		}
 
@@ -155,16 +160,28 @@ func (b *B) Aggregate() (aggregate *bobfile.Bobfile, err error) {
 	// Merge runs into one Bobfile
 	aggregate = b.addRunTasksToAggregate(aggregate, bobs)
 
+	b.evaluateConditions(aggregate)
+
+	// Logs are kept per project as task names are only unique within a project.
+	logStore := b.logStore.Project(b.dir)
+
 	// Assure tasks are correctly initialised.
 	for i, task := range aggregate.BTasks {
 		task.WithLocalstore(b.local)
 		task.WithEnvStore(b.nix.EnvStore())
 		task.WithBuildinfoStore(b.buildInfoStore)
+		task.WithLogStore(logStore)
 
 		// a task must always-rebuild when caching is disabled
 		if !b.enableCaching {
 			task.SetRebuildStrategy(bobtask.RebuildAlways)
 		}
+
+		// apply the default timeout to tasks without their own
+		if task.Timeout() == 0 {
+			task.SetTimeout(b.taskTimeout)
+		}
+		task.SetKillTimeout(b.killTimeout)
 		aggregate.BTasks[i] = task
 	}
 
@@ -186,6 +203,9 @@ func (b *B) Aggregate() (aggregate *bobfile.Bobfile, err error) {
 	aggregate.Dependencies = make([]string, 0)
 	aggregate.Dependencies = append(aggregate.Dependencies, allDeps...)
 
+	aggregate.Resources, err = aggregateResources(aggregate, bobs)
+	errz.Fatal(err)
+
 	// Initialize remote store in case of a valid remote url / project name
 	if aggregate.Project != "" {
 		projectName, err := project.Parse(aggregate.Project)
@@ -206,7 +226,7 @@ func (b *B) Aggregate() (aggregate *bobfile.Bobfile, err error) {
 			authCtx, err := b.CurrentAuthContext()
 			if err != nil {
 				if errors.Is(err, auth.ErrNotFound) {
-					fmt.Printf("Will not sync to %s because of missing auth context\n", projectName)
+					boblog.Log.Info(fmt.Sprintf("Will not sync to %s because of missing auth context", projectName))
 				} else {
 					return nil, err
 				}
@@ -3,10 +3,12 @@ package bob
 import (
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/benchkram/bob/bob/bobfile"
+	"github.com/benchkram/bob/pkg/envutil"
 	"github.com/benchkram/bob/pkg/usererror"
 	"github.com/benchkram/errz"
 )
@@ -155,3 +157,31 @@ func readImports(
 
 	return imports, nil
 }
+
+// aggregateResources merges the resource capacities declared in all bobfiles.
+// A resource declared with different capacities is an error.
+func aggregateResources(a *bobfile.Bobfile, bobs []*bobfile.Bobfile) (map[string]int, error) {
+	resources := make(map[string]int)
+	for _, boblet := range append([]*bobfile.Bobfile{a}, bobs...) {
+		for name, capacity := range boblet.Resources {
+			if c, ok := resources[name]; ok && c != capacity {
+				return nil, usererror.Wrap(fmt.Errorf("%w, resource `%s` declared with capacity %d and %d", ErrResourceCapacityConflict, name, c, capacity))
+			}
+			resources[name] = capacity
+		}
+	}
+	return resources, nil
+}
+
+// evaluateConditions disables build tasks whose `when` condition is not met.
+// Conditions are evaluated on the host environment, overridden by `--env`.
+func (b *B) evaluateConditions(a *bobfile.Bobfile) {
+	env := envutil.Merge(os.Environ(), b.env)
+	for name, task := range a.BTasks {
+		if task.When == nil {
+			continue
+		}
+		task.SetDisabled(!task.When.Met(env))
+		a.BTasks[name] = task
+	}
+}
@@ -3,10 +3,15 @@ package bob
 import (
 	"os"
 	"runtime"
+	"time"
 
 	nixbuilder "github.com/benchkram/bob/bob/nix-builder"
+	"github.com/benchkram/bob/bob/playbook"
+	"github.com/benchkram/bob/bobtask"
 	"github.com/benchkram/bob/pkg/auth"
 	"github.com/benchkram/bob/pkg/dockermobyutil"
+	"github.com/benchkram/bob/pkg/history"
+	"github.com/benchkram/bob/pkg/logstore"
 	"github.com/benchkram/bob/pkg/usererror"
 
 	"github.com/hashicorp/go-version"
@@ -56,12 +61,36 @@ type B struct {
 	// enablePull enables the artifacts download from remote store
 	enablePull bool
 
+	// keepGoing continues building independent tasks after a task failed
+	keepGoing bool
+
+	// replayOutput prints the captured output of tasks which are not rebuilt
+	replayOutput bool
+
+	// taskTimeout is the default timeout for tasks without a timeout
+	taskTimeout time.Duration
+
+	// killTimeout is the time canceled tasks are given to terminate before they are killed
+	killTimeout time.Duration
+
+	// eventHandler receives the events emitted during a build
+	eventHandler playbook.EventHandler
+
+	// traceFile a chrome trace of the build is written to
+	traceFile string
+
 	// nix builds dependencies for tasks
 	nix *nixbuilder.NB
 
 	// authStore is used to store authentication credentials for remote store
 	authStore *auth.Store
 
+	// historyStore records every playbook run
+	historyStore *history.Store
+
+	// logStore keeps the output of build tasks
+	logStore *logstore.Store
+
 	// env is a list of strings representing the environment in the form "key=value"
 	env []string
 
@@ -81,6 +110,7 @@ func newBob(opts ...Option) *B {
 		enableCaching: true,
 		allowInsecure: false,
 		maxParallel:   runtime.NumCPU(),
+		killTimeout:   bobtask.DefaultKillTimeout,
 	}
 
 	for _, opt := range opts {
@@ -120,6 +150,18 @@ func BobWithBaseStoreDir(baseStoreDir string, opts ...Option) (*B, error) {
 	}
 	bob.authStore = authStore
 
+	historyStore, err := HistoryStore(baseStoreDir)
+	if err != nil {
+		return nil, err
+	}
+	bob.historyStore = historyStore
+
+	logStore, err := LogStore(baseStoreDir)
+	if err != nil {
+		return nil, err
+	}
+	bob.logStore = logStore
+
 	nixBuilder, err := NixBuilder(baseStoreDir)
 	if err != nil {
 		return nil, err
@@ -183,6 +225,22 @@ func Bob(opts ...Option) (*B, error) {
 		bob.authStore = fs
 	}
 
+	if bob.historyStore == nil {
+		hs, err := DefaultHistoryStore()
+		if err != nil {
+			return nil, err
+		}
+		bob.historyStore = hs
+	}
+
+	if bob.logStore == nil {
+		ls, err := DefaultLogStore()
+		if err != nil {
+			return nil, err
+		}
+		bob.logStore = ls
+	}
+
 	return bob, nil
 }
 
@@ -10,6 +10,8 @@ import (
 	nixbuilder "github.com/benchkram/bob/bob/nix-builder"
 	"github.com/benchkram/bob/pkg/auth"
 	"github.com/benchkram/bob/pkg/buildinfostore"
+	"github.com/benchkram/bob/pkg/history"
+	"github.com/benchkram/bob/pkg/logstore"
 	"github.com/benchkram/bob/pkg/nix"
 	"github.com/benchkram/bob/pkg/store"
 	"github.com/benchkram/bob/pkg/store/filestore"
@@ -87,6 +89,44 @@ func DefaultAuthStore() (s *auth.Store, err error) {
 	return AuthStore(home)
 }
 
+func HistoryStore(baseDir string) (s *history.Store, err error) {
+	defer errz.Recover(&err)
+
+	storeDir := filepath.Join(baseDir, global.BobCacheHistoryDir)
+	err = os.MkdirAll(storeDir, 0775)
+	errz.Fatal(err)
+
+	return history.New(storeDir), nil
+}
+
+func DefaultHistoryStore() (s *history.Store, err error) {
+	defer errz.Recover(&err)
+
+	home, err := os.UserHomeDir()
+	errz.Fatal(err)
+
+	return HistoryStore(home)
+}
+
+func LogStore(baseDir string) (s *logstore.Store, err error) {
+	defer errz.Recover(&err)
+
+	storeDir := filepath.Join(baseDir, global.BobCacheLogsDir)
+	err = os.MkdirAll(storeDir, 0775)
+	errz.Fatal(err)
+
+	return logstore.New(storeDir), nil
+}
+
+func DefaultLogStore() (s *logstore.Store, err error) {
+	defer errz.Recover(&err)
+
+	home, err := os.UserHomeDir()
+	errz.Fatal(err)
+
+	return LogStore(home)
+}
+
 // NixBuilder initialises a new nix builder object with the cache setup
 // in the given location.
 //
@@ -39,6 +39,7 @@ var (
 	ErrDuplicateTaskName      = fmt.Errorf("duplicate task name")
 	ErrInvalidProjectName     = fmt.Errorf("invalid project name")
 	ErrSelfReference          = fmt.Errorf("self reference")
+	ErrInvalidResource        = fmt.Errorf("invalid resource")
 
 	ErrInvalidRunType = fmt.Errorf("Invalid run type")
 
@@ -58,8 +59,14 @@ type Bobfile struct {
 	Imports []string `yaml:"import,omitempty"`
 
 	// Variables is a map of variables that can be used in the tasks.
+	// `${NAME}` references them in input, target, dependsOn,
+	// dependencies and env of tasks and in the path of runs.
 	Variables VariableMap
 
+	// Templates are task definitions build tasks can extend,
+	// available to this Bobfile and the ones importing it.
+	Templates bobtask.Templates `yaml:"templates,omitempty"`
+
 	// BTasks build tasks
 	BTasks bobtask.Map `yaml:"build"`
 	// RTasks run tasks
@@ -70,13 +77,18 @@ type Bobfile struct {
 	// Nixpkgs specifies an optional nixpkgs source.
 	Nixpkgs string `yaml:"nixpkgs"`
 
+	// Resources maps a resource name to its capacity. Tasks occupying
+	// a resource are never run concurrently beyond its capacity.
+	// Resources without a declared capacity are exclusive (capacity 1).
+	Resources map[string]int `yaml:"resources,omitempty"`
+
 	// Parent directory of the Bobfile.
 	// Populated through BobfileRead().
 	dir string
 
 	bobfiles []*Bobfile
 
-	RemoteStoreHost string
+	RemoteStoreHost string `yaml:"-"`
 	remotestore     store.Store
 }
 
@@ -138,6 +150,23 @@ func bobfileRead(dir string) (_ *Bobfile, err error) {
 		bobfile.RTasks = bobrun.RunMap{}
 	}
 
+	templates, err := bobfile.templates()
+	errz.Fatal(err)
+	err = bobfile.BTasks.Extend(templates)
+	if err != nil {
+		return nil, usererror.Wrap(err)
+	}
+
+	err = bobfile.BTasks.ExpandMatrices()
+	if err != nil {
+		return nil, usererror.Wrap(err)
+	}
+
+	err = bobfile.interpolate()
+	if err != nil {
+		return nil, usererror.Wrap(err)
+	}
+
 	// Assure tasks are initialized with their defaults
 	for key, task := range bobfile.BTasks {
 		task.SetDir(bobfile.dir)
@@ -291,6 +320,12 @@ func (b *Bobfile) Validate() (err error) {
 		}
 	}
 
+	for name, capacity := range b.Resources {
+		if capacity < 1 {
+			return usererror.Wrap(fmt.Errorf("%w `%s`: capacity must be greater than 0", ErrInvalidResource, name))
+		}
+	}
+
 	// use for duplicate names validation
 	names := map[string]bool{}
 
@@ -2,9 +2,13 @@ package bobfile_test
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/benchkram/bob/bob/bobfile"
+	"github.com/benchkram/bob/bob/global"
 	"github.com/benchkram/bob/bobrun"
 	"github.com/benchkram/bob/bobtask"
 )
@@ -75,3 +79,256 @@ func TestBobfileProjectName(t *testing.T) {
 		}
 	}
 }
+
+func TestBobfileInterpolateVariables(t *testing.T) {
+	dir := t.TempDir()
+
+	content := `variables:
+  OUT_DIR: build
+build:
+  build:
+    input: src/*
+    cmd: echo ${HOME} > ${OUT_DIR}/out
+    target: ${OUT_DIR}/out
+    env:
+      LITERAL: $${OUT_DIR}
+`
+	if err := os.WriteFile(filepath.Join(dir, global.BobFileName), []byte(content), 0664); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := bobfile.BobfileRead(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	task := b.BTasks["build"]
+	if task.TargetDirty != "build/out" {
+		t.Errorf("expected target `build/out`, got %v", task.TargetDirty)
+	}
+	if task.CmdDirty != "echo ${HOME} > ${OUT_DIR}/out" {
+		t.Errorf("expected cmd to be untouched, got %q", task.CmdDirty)
+	}
+	if task.EnvDirty["LITERAL"] != "${OUT_DIR}" {
+		t.Errorf("expected escaped env value, got %q", task.EnvDirty["LITERAL"])
+	}
+
+	content = `build:
+  build:
+    target: ${UNDEFINED}/out
+`
+	if err := os.WriteFile(filepath.Join(dir, global.BobFileName), []byte(content), 0664); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = bobfile.BobfileRead(dir)
+	if !errors.Is(err, bobfile.ErrUndefinedVariable) {
+		t.Fatalf("expected ErrUndefinedVariable, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "`UNDEFINED`") {
+		t.Errorf("expected error to name the variable, got %q", err)
+	}
+}
+
+func TestBobfileInterpolateMatrix(t *testing.T) {
+	dir := t.TempDir()
+
+	content := `build:
+  gen-linux:
+    cmd: echo linux
+  gen-darwin:
+    cmd: echo darwin
+  build:
+    matrix:
+      GOOS: [linux, darwin]
+    cmd: go build -o bin/${GOOS}/app
+    dependsOn:
+      - gen-${GOOS}
+    env:
+      OUT: bin/${GOOS}
+`
+	if err := os.WriteFile(filepath.Join(dir, global.BobFileName), []byte(content), 0664); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := bobfile.BobfileRead(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, goos := range []string{"linux", "darwin"} {
+		task := b.BTasks["build["+goos+"]"]
+		if len(task.DependsOn) != 1 || task.DependsOn[0] != "gen-"+goos {
+			t.Errorf("expected build[%s] to depend on gen-%s, got %v", goos, goos, task.DependsOn)
+		}
+		if task.EnvDirty["OUT"] != "bin/"+goos {
+			t.Errorf("expected env OUT of build[%s] to be bin/%s, got %q", goos, goos, task.EnvDirty["OUT"])
+		}
+	}
+}
+
+func TestSchemaInternalFields(t *testing.T) {
+	s := bobfile.NewSchema()
+
+	if _, ok := s.Properties["remotestorehost"]; ok {
+		t.Error("expected `remotestorehost` not to be part of the schema")
+	}
+	for _, key := range []string{"taskid", "dependsonids"} {
+		if _, ok := s.Defs["task"].Properties[key]; ok {
+			t.Errorf("expected `%s` not to be part of the task schema", key)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+
+	content := `import: [sub]
+build:
+  build:
+    dependson: [sub/gen, nope]
+    retries: three
+    retryDelay: 1s
+`
+	if err := os.WriteFile(filepath.Join(dir, global.BobFileName), []byte(content), 0664); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0775); err != nil {
+		t.Fatal(err)
+	}
+	content = `build:
+  gen:
+    target: {path: a, image: b}
+  lint:
+    dependsOn: [missing]
+`
+	if err := os.WriteFile(filepath.Join(dir, "sub", global.BobFileName), []byte(content), 0664); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := bobfile.Check(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		filepath.Join(dir, global.BobFileName) + ":5:14: build.build.retries: expected an integer, got a string",
+		filepath.Join(dir, global.BobFileName) + ":6:5: build.build.retryDelay: unknown key `retryDelay`, did you mean `retry_delay`?",
+		filepath.Join(dir, "sub", global.BobFileName) + ":3:13: build.gen.target: " + bobtask.ErrAmbigousTargetDefinition.Error(),
+		// the dependencies of a readable bobfile are checked despite its problems
+		filepath.Join(dir, "sub", global.BobFileName) + ":5:17: build.lint.dependsOn[0]: unknown task `missing`",
+	}
+	if len(problems) != len(expected) {
+		t.Fatalf("expected %d problems, got %v", len(expected), problems)
+	}
+	for i, p := range problems {
+		if p.String() != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], p.String())
+		}
+	}
+
+	// unknown tasks are reported in every readable bobfile
+	content = `build:
+  gen:
+    target: a
+`
+	if err := os.WriteFile(filepath.Join(dir, "sub", global.BobFileName), []byte(content), 0664); err != nil {
+		t.Fatal(err)
+	}
+	content = `import: [sub]
+build:
+  build:
+    dependson: [sub/gen, nope]
+`
+	if err := os.WriteFile(filepath.Join(dir, global.BobFileName), []byte(content), 0664); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err = bobfile.Check(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 1 || problems[0].Message != "build.build.dependson[1]: unknown task `nope`" {
+		t.Errorf("expected unknown task `nope`, got %v", problems)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	input := `build:
+    # builds the binary
+    build:
+        target: bin/app # the binary
+        cmd: |
+            go build -o bin/app   
+
+        dependsOn: [generate]
+    generate:
+        input: |-
+            *.proto
+
+            buf.yaml
+        cmd: buf generate
+version: 0.1.0
+`
+	expected := `version: 0.1.0
+build:
+  # builds the binary
+  build:
+    cmd: go build -o bin/app
+    dependsOn: [generate]
+    target: bin/app # the binary
+  generate:
+    input: |
+      *.proto
+
+      buf.yaml
+    cmd: buf generate
+`
+
+	formatted, err := bobfile.Format([]byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(formatted) != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, formatted)
+	}
+
+	formatted, err = bobfile.Format(formatted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(formatted) != expected {
+		t.Errorf("expected formatting to be stable, got:\n%s", formatted)
+	}
+}
+
+func TestBobfileVerifyCycle(t *testing.T) {
+	b := bobfile.NewBobfile()
+
+	b.BTasks["a"] = bobtask.Task{DependsOn: []string{"sub/b"}}
+	b.BTasks["sub/b"] = bobtask.Task{DependsOn: []string{"sub/c"}}
+	b.BTasks["sub/c"] = bobtask.Task{DependsOn: []string{"a"}}
+	b.RTasks["server"] = &bobrun.Run{DependsOn: []string{"a"}}
+
+	err := b.Verify()
+	if !errors.Is(err, bobfile.ErrCyclicDependency) {
+		t.Fatalf("expected ErrCyclicDependency, got %v", err)
+	}
+	if !strings.HasSuffix(err.Error(), "a -> sub/b -> sub/c -> a") {
+		t.Errorf("expected the cycle to be printed, got %q", err)
+	}
+
+	// a cycle through a run task
+	b = bobfile.NewBobfile()
+	b.BTasks["a"] = bobtask.Task{}
+	b.RTasks["server"] = &bobrun.Run{DependsOn: []string{"a", "db"}}
+	b.RTasks["db"] = &bobrun.Run{DependsOn: []string{"server"}}
+
+	err = b.Verify()
+	if !errors.Is(err, bobfile.ErrCyclicDependency) {
+		t.Fatalf("expected ErrCyclicDependency, got %v", err)
+	}
+	if !strings.HasSuffix(err.Error(), "db -> server -> db") {
+		t.Errorf("expected the cycle to be printed, got %q", err)
+	}
+}
@@ -0,0 +1,439 @@
+package bobfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/benchkram/bob/bob/global"
+	"github.com/benchkram/bob/bobtask"
+	"github.com/benchkram/bob/pkg/file"
+)
+
+// Problem is an issue found in a Bobfile.
+type Problem struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", p.File, p.Line, p.Column, p.Message)
+}
+
+// Check validates the Bobfile in dir and all Bobfiles it imports. Keys
+// and values are checked against the schema, tasks are checked to depend
+// on existing tasks only. Problems are returned in order of their position.
+func Check(dir string) (_ []Problem, err error) {
+	c := &checker{
+		schema:  NewSchema(),
+		visited: map[string]bool{},
+	}
+
+	err = c.checkFile(dir, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	c.checkDependsOn()
+
+	sort.SliceStable(c.problems, func(i, j int) bool {
+		a, b := c.problems[i], c.problems[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+
+	return c.problems, nil
+}
+
+type checker struct {
+	schema   *Schema
+	problems []Problem
+
+	// files holds the checked bobfiles in the order they are read
+	files   []*checkedFile
+	visited map[string]bool
+}
+
+type checkedFile struct {
+	dir  string
+	path string
+	root *yaml.Node
+	// bobfile as read for execution
+	bobfile *Bobfile
+}
+
+func (c *checker) report(path string, node *yaml.Node, format string, a ...interface{}) {
+	c.problems = append(c.problems, Problem{
+		File:    path,
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf(format, a...),
+	})
+}
+
+var yamlErrorLine = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// checkFile checks the bobfile in dir and follows its imports.
+// importedBy is the node importing the bobfile, nil for the top level.
+func (c *checker) checkFile(dir string, importedBy *yaml.Node) error {
+	if c.visited[dir] {
+		return nil
+	}
+	c.visited[dir] = true
+
+	path := filepath.Join(dir, global.BobFileName)
+	if !file.Exists(path) {
+		if importedBy == nil {
+			return ErrBobfileNotFound
+		}
+		return nil
+	}
+
+	bin, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var doc yaml.Node
+	err = yaml.Unmarshal(bin, &doc)
+	if err != nil {
+		line, message := 1, err.Error()
+		if m := yamlErrorLine.FindStringSubmatch(message); m != nil {
+			line, _ = strconv.Atoi(m[1])
+			message = m[2]
+		}
+		c.problems = append(c.problems, Problem{File: path, Line: line, Column: 1, Message: message})
+		c.files = append(c.files, &checkedFile{dir: dir, path: path})
+		return nil
+	}
+	if len(doc.Content) == 0 {
+		// an empty bobfile is valid
+		return nil
+	}
+	root := doc.Content[0]
+
+	problems := len(c.problems)
+	c.validate(path, root, c.schema, "")
+	c.checkTasks(path, root)
+
+	f := &checkedFile{dir: dir, path: path, root: root}
+	c.files = append(c.files, f)
+
+	if len(c.problems) == problems {
+		_, err = BobfileRead(dir)
+		if err != nil {
+			c.report(path, root, "%s", err.Error())
+		}
+	}
+	// dependencies are checked for every bobfile which can be read,
+	// whether it has problems or not
+	f.bobfile, _ = BobfileReadPlain(dir)
+
+	if imports := value(root, "import"); imports != nil && imports.Kind == yaml.SequenceNode {
+		for _, item := range imports.Content {
+			importDir := filepath.Join(dir, item.Value)
+			if !file.Exists(filepath.Join(importDir, global.BobFileName)) {
+				c.report(path, item, "import `%s`: %s", item.Value, ErrBobfileNotFound)
+				continue
+			}
+			err = c.checkFile(importDir, item)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validate checks node against schema s.
+func (c *checker) validate(path string, node *yaml.Node, s *Schema, at string) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	s = s.resolve(c.schema)
+
+	if node.Tag == "!!null" {
+		// yaml reads null as the zero value
+		return
+	}
+
+	prefix := ""
+	if at != "" {
+		prefix = at + ": "
+	}
+
+	if len(s.OneOf) > 0 {
+		expected := []string{}
+		for _, alternative := range s.OneOf {
+			alternative = alternative.resolve(c.schema)
+			if matchesType(alternative.Type, node) {
+				c.validate(path, node, alternative, at)
+				return
+			}
+			expected = append(expected, typeName(alternative.Type))
+		}
+		c.report(path, node, "%sexpected %s, got %s", prefix, strings.Join(expected, " or "), nodeTypeName(node))
+		return
+	}
+
+	if s.Type != "" && !matchesType(s.Type, node) {
+		c.report(path, node, "%sexpected %s, got %s", prefix, typeName(s.Type), nodeTypeName(node))
+		return
+	}
+
+	if len(s.Enum) > 0 {
+		for _, e := range s.Enum {
+			if node.Value == e {
+				return
+			}
+		}
+		c.report(path, node, "%sinvalid value `%s`, must be one of %s", prefix, node.Value, strings.Join(s.Enum, ", "))
+		return
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		seen := map[string]bool{}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, val := node.Content[i], node.Content[i+1]
+			if key.Tag == "!!merge" {
+				c.validate(path, val, s, at)
+				continue
+			}
+
+			keyAt := key.Value
+			if at != "" {
+				keyAt = at + "." + key.Value
+			}
+
+			if seen[key.Value] {
+				c.report(path, key, "%s: duplicate key", keyAt)
+				continue
+			}
+			seen[key.Value] = true
+
+			if property, ok := s.Properties[key.Value]; ok {
+				c.validate(path, val, property, keyAt)
+				continue
+			}
+
+			switch additional := s.AdditionalProperties.(type) {
+			case *Schema:
+				c.validate(path, val, additional, keyAt)
+			case bool:
+				if additional {
+					continue
+				}
+				if suggestion := suggest(key.Value, s.Properties); suggestion != "" {
+					c.report(path, key, "%s: unknown key `%s`, did you mean `%s`?", keyAt, key.Value, suggestion)
+				} else {
+					c.report(path, key, "%s: unknown key `%s`", keyAt, key.Value)
+				}
+			}
+		}
+	case yaml.SequenceNode:
+		if s.Items == nil {
+			return
+		}
+		for i, item := range node.Content {
+			c.validate(path, item, s.Items, fmt.Sprintf("%s[%d]", at, i))
+		}
+	}
+}
+
+// checkTasks checks build tasks, templates and runs
+// for problems not expressed in the schema.
+func (c *checker) checkTasks(path string, root *yaml.Node) {
+	for _, section := range []string{"build", "templates", "run"} {
+		m := value(root, section)
+		if m == nil || m.Kind != yaml.MappingNode {
+			continue
+		}
+
+		for i := 0; i+1 < len(m.Content); i += 2 {
+			at := section + "." + m.Content[i].Value
+			task := m.Content[i+1]
+			if task.Kind != yaml.MappingNode {
+				continue
+			}
+
+			if value(task, "dependsOn") != nil && value(task, "dependson") != nil {
+				c.report(path, task, "%s: both `dependson` and `dependsOn` are set", at)
+			}
+
+			if section == "run" {
+				continue
+			}
+
+			if target := value(task, "target"); target != nil && target.Kind == yaml.MappingNode {
+				hasPath, hasImage := value(target, "path") != nil, value(target, "image") != nil
+				if hasPath && hasImage {
+					c.report(path, target, "%s.target: %s", at, bobtask.ErrAmbigousTargetDefinition)
+				} else if !hasPath && !hasImage {
+					c.report(path, target, "%s.target: %s", at, bobtask.ErrInvalidTargetDefinition)
+				}
+			}
+
+			for _, key := range []string{"timeout", "retry_delay"} {
+				if d := value(task, key); d != nil && d.Kind == yaml.ScalarNode && d.Value != "" {
+					if _, err := time.ParseDuration(d.Value); err != nil {
+						c.report(path, d, "%s.%s: invalid duration `%s`", at, key, d.Value)
+					}
+				}
+			}
+		}
+	}
+}
+
+// checkDependsOn reports dependencies on tasks which don't exist.
+// Bobfiles which could not be read are skipped, so are dependencies
+// on their tasks.
+func (c *checker) checkDependsOn() {
+	names := map[string]bool{}
+	unreadable := map[string]bool{}
+	for _, f := range c.files {
+		if f.bobfile == nil {
+			unreadable[f.dir] = true
+			continue
+		}
+		for name := range f.bobfile.BTasks {
+			names[filepath.Join(f.dir, name)] = true
+		}
+		for name := range f.bobfile.RTasks {
+			names[filepath.Join(f.dir, name)] = true
+		}
+	}
+
+	for _, f := range c.files {
+		if f.bobfile == nil {
+			continue
+		}
+		for _, section := range []string{"build", "run"} {
+			m := value(f.root, section)
+			if m == nil || m.Kind != yaml.MappingNode {
+				continue
+			}
+			for i := 0; i+1 < len(m.Content); i += 2 {
+				task := m.Content[i+1]
+				if task.Kind != yaml.MappingNode {
+					continue
+				}
+				for _, key := range []string{"dependsOn", "dependson"} {
+					dependsOn := value(task, key)
+					if dependsOn == nil || dependsOn.Kind != yaml.SequenceNode {
+						continue
+					}
+					for j, item := range dependsOn.Content {
+						dep, err := f.bobfile.Variables.interpolate(item.Value)
+						if err != nil {
+							// reported when reading the bobfile
+							continue
+						}
+						path := filepath.Join(f.dir, dep)
+						if !names[path] && !unreadable[filepath.Dir(path)] {
+							c.report(f.path, item, "%s.%s.%s[%d]: unknown task `%s`", section, m.Content[i].Value, key, j, dep)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// value returns the value of key in a mapping node.
+func value(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func matchesType(t string, node *yaml.Node) bool {
+	switch t {
+	case "":
+		return true
+	case "object":
+		return node.Kind == yaml.MappingNode
+	case "array":
+		return node.Kind == yaml.SequenceNode
+	case "string":
+		// yaml reads any scalar into a string
+		return node.Kind == yaml.ScalarNode
+	case "integer":
+		return node.Kind == yaml.ScalarNode && node.Tag == "!!int"
+	case "number":
+		return node.Kind == yaml.ScalarNode && (node.Tag == "!!int" || node.Tag == "!!float")
+	case "boolean":
+		return node.Kind == yaml.ScalarNode && node.Tag == "!!bool"
+	}
+	return false
+}
+
+func typeName(t string) string {
+	switch t {
+	case "object":
+		return "a map"
+	case "array":
+		return "a list"
+	case "integer":
+		return "an integer"
+	default:
+		return "a " + t
+	}
+}
+
+func nodeTypeName(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a map"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+	switch node.Tag {
+	case "!!int":
+		return "an integer"
+	case "!!float":
+		return "a number"
+	case "!!bool":
+		return "a boolean"
+	}
+	return "a string"
+}
+
+// suggest returns a known key which differs from key only
+// in case or separators, e.g. `retry_delay` for `retryDelay`.
+func suggest(key string, properties map[string]*Schema) string {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(s))
+	}
+
+	known := make([]string, 0, len(properties))
+	for property := range properties {
+		known = append(known, property)
+	}
+	sort.Strings(known)
+
+	for _, property := range known {
+		if normalize(property) == normalize(key) {
+			return property
+		}
+	}
+	return ""
+}
@@ -0,0 +1,249 @@
+package bobfile
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/benchkram/bob/bob/global"
+	"github.com/benchkram/bob/pkg/file"
+)
+
+// Canonical key order of a Bobfile, its tasks and runs.
+// Unknown keys are kept after the known ones.
+var (
+	bobfileKeyOrder = []string{
+		"version", "project", "import", "nixpkgs", "dependencies",
+		"variables", "resources", "templates", "build", "run",
+	}
+	taskKeyOrder = []string{
+		"extends", "matrix", "when",
+		"input", "input_additional_ignores", "cmd", "shell", "env_file", "env",
+		"dependsOn", "dependson", "dependencies", "target",
+		"rebuild", "timeout", "retries", "retry_delay", "resources",
+	}
+	runKeyOrder = []string{
+		"type", "path", "shell", "dependsOn", "dependson", "dependencies", "init", "initOnce",
+	}
+
+	// blockKeys are task keys holding one entry per line.
+	blockKeys = []string{"input", "cmd", "target"}
+)
+
+// Format returns the canonical form of a bob.yaml. Top level keys and task
+// keys are brought into a stable order, tasks, runs and variables are sorted
+// by name and multiline input, cmd and target values are normalised into
+// literal blocks. Comments are preserved.
+func Format(bin []byte) ([]byte, error) {
+	var doc yaml.Node
+	err := yaml.Unmarshal(bin, &doc)
+	if err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode || len(doc.Content[0].Content) == 0 {
+		return bin, nil
+	}
+	root := doc.Content[0]
+
+	first := root.Content[0]
+	orderKeys(root, bobfileKeyOrder)
+	if root.Content[0] != first && first.HeadComment != "" {
+		// a comment on top of the file stays on top
+		doc.HeadComment = strings.TrimPrefix(doc.HeadComment+"\n"+first.HeadComment, "\n")
+		first.HeadComment = ""
+	}
+
+	if variables := value(root, "variables"); variables != nil {
+		sortKeys(variables)
+	}
+
+	for _, section := range []string{"build", "templates"} {
+		tasks := value(root, section)
+		if tasks == nil || tasks.Kind != yaml.MappingNode {
+			continue
+		}
+		sortKeys(tasks)
+		for i := 1; i < len(tasks.Content); i += 2 {
+			formatTask(tasks.Content[i])
+		}
+	}
+
+	if runs := value(root, "run"); runs != nil && runs.Kind == yaml.MappingNode {
+		sortKeys(runs)
+		for i := 1; i < len(runs.Content); i += 2 {
+			orderKeys(runs.Content[i], runKeyOrder)
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	err = enc.Encode(&doc)
+	if err != nil {
+		return nil, err
+	}
+	err = enc.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func formatTask(task *yaml.Node) {
+	if task.Kind != yaml.MappingNode {
+		return
+	}
+	orderKeys(task, taskKeyOrder)
+
+	if env := value(task, "env"); env != nil {
+		sortKeys(env)
+	}
+
+	for _, key := range blockKeys {
+		v := value(task, key)
+		if v == nil {
+			continue
+		}
+		if v.Kind == yaml.MappingNode {
+			// e.g. `target: {path: ...}`
+			for i := 1; i < len(v.Content); i += 2 {
+				normalizeBlock(v.Content[i])
+			}
+			continue
+		}
+		normalizeBlock(v)
+	}
+}
+
+// normalizeBlock strips trailing whitespace and empty leading and trailing
+// lines of a string. Multiline strings are written as literal block,
+// single lines as plain scalar.
+func normalizeBlock(n *yaml.Node) {
+	if n.Kind != yaml.ScalarNode || n.Tag != "!!str" {
+		return
+	}
+
+	lines := strings.Split(n.Value, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\r")
+	}
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	switch len(lines) {
+	case 0:
+		n.Value = ""
+	case 1:
+		n.Value = lines[0]
+		if n.Style == yaml.LiteralStyle || n.Style == yaml.FoldedStyle {
+			n.Style = 0
+		}
+	default:
+		n.Value = strings.Join(lines, "\n") + "\n"
+		n.Style = yaml.LiteralStyle
+	}
+}
+
+// orderKeys sorts the keys of a mapping node by their position in order.
+func orderKeys(m *yaml.Node, order []string) {
+	if m.Kind != yaml.MappingNode {
+		return
+	}
+
+	position := make(map[string]int, len(order))
+	for i, key := range order {
+		position[key] = i
+	}
+	rank := func(key string) int {
+		if p, ok := position[key]; ok {
+			return p
+		}
+		return len(order)
+	}
+
+	sortPairs(m, func(a, b string) bool {
+		return rank(a) < rank(b)
+	})
+}
+
+// sortKeys sorts the keys of a mapping node alphabetically.
+func sortKeys(m *yaml.Node) {
+	if m.Kind != yaml.MappingNode {
+		return
+	}
+	sortPairs(m, func(a, b string) bool {
+		return a < b
+	})
+}
+
+// sortPairs stable sorts the key value pairs of a mapping node.
+func sortPairs(m *yaml.Node, less func(a, b string) bool) {
+	pairs := make([][2]*yaml.Node, 0, len(m.Content)/2)
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		pairs = append(pairs, [2]*yaml.Node{m.Content[i], m.Content[i+1]})
+	}
+
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return less(pairs[i][0].Value, pairs[j][0].Value)
+	})
+
+	m.Content = m.Content[:0]
+	for _, pair := range pairs {
+		m.Content = append(m.Content, pair[0], pair[1])
+	}
+}
+
+// Files returns the paths of the bob.yaml in dir
+// and of all bob.yaml it imports.
+func Files(dir string) ([]string, error) {
+	files := []string{}
+	err := collectFiles(dir, &files, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+func collectFiles(dir string, files *[]string, visited map[string]bool) error {
+	if visited[dir] {
+		return nil
+	}
+	visited[dir] = true
+
+	bobfilePath := filepath.Join(dir, global.BobFileName)
+	if !file.Exists(bobfilePath) {
+		return ErrBobfileNotFound
+	}
+	*files = append(*files, bobfilePath)
+
+	bin, err := os.ReadFile(bobfilePath)
+	if err != nil {
+		return err
+	}
+
+	var partial struct {
+		Imports []string `yaml:"import"`
+	}
+	err = yaml.Unmarshal(bin, &partial)
+	if err != nil {
+		return err
+	}
+
+	for _, importPath := range partial.Imports {
+		err = collectFiles(filepath.Join(dir, importPath), files, visited)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package bobfile
+
+import (
+	"fmt"
+	"strings"
+)
+
+var ErrUndefinedVariable = fmt.Errorf("undefined variable")
+
+// interpolate replaces `${NAME}` in s by the value of variable NAME.
+// `$${` escapes a literal `${`.
+func (vm VariableMap) interpolate(s string) (string, error) {
+	var sb strings.Builder
+	for {
+		i := strings.Index(s, "${")
+		if i < 0 {
+			sb.WriteString(s)
+			break
+		}
+
+		// escaped `$${`
+		if i > 0 && s[i-1] == '$' {
+			sb.WriteString(s[:i-1])
+			sb.WriteString("${")
+			s = s[i+2:]
+			continue
+		}
+
+		end := strings.Index(s[i+2:], "}")
+		if end < 0 {
+			// unterminated, keep as is
+			sb.WriteString(s)
+			break
+		}
+		name := s[i+2 : i+2+end]
+
+		value, ok := vm[name]
+		if !ok {
+			return "", fmt.Errorf("%w `%s`", ErrUndefinedVariable, name)
+		}
+
+		sb.WriteString(s[:i])
+		sb.WriteString(value)
+		s = s[i+2+end+1:]
+	}
+
+	return sb.String(), nil
+}
+
+func (vm VariableMap) interpolateSlice(s []string) ([]string, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	interpolated := make([]string, 0, len(s))
+	for _, v := range s {
+		v, err := vm.interpolate(v)
+		if err != nil {
+			return nil, err
+		}
+		interpolated = append(interpolated, v)
+	}
+	return interpolated, nil
+}
+
+// interpolate replaces references to the Bobfile's variables in
+// input, target, dependsOn, dependencies and env of build tasks and
+// in path, dependsOn and dependencies of run tasks.
+// Commands are left untouched, they are expanded by the shell.
+func (b *Bobfile) interpolate() (err error) {
+	vars := b.Variables
+
+	b.Dependencies, err = vars.interpolateSlice(b.Dependencies)
+	if err != nil {
+		return fmt.Errorf("%w in `dependencies`", err)
+	}
+
+	for name, task := range b.BTasks {
+		fail := func(err error, field string) error {
+			return fmt.Errorf("%w in `%s` of task `%s`", err, field, name)
+		}
+
+		task.InputDirty, err = vars.interpolate(task.InputDirty)
+		if err != nil {
+			return fail(err, "input")
+		}
+
+		switch target := task.TargetDirty.(type) {
+		case string:
+			task.TargetDirty, err = vars.interpolate(target)
+			if err != nil {
+				return fail(err, "target")
+			}
+		case map[string]interface{}:
+			interpolated := make(map[string]interface{}, len(target))
+			for key, value := range target {
+				if s, ok := value.(string); ok {
+					value, err = vars.interpolate(s)
+					if err != nil {
+						return fail(err, "target")
+					}
+				}
+				interpolated[key] = value
+			}
+			task.TargetDirty = interpolated
+		}
+
+		task.DependsOn, err = vars.interpolateSlice(task.DependsOn)
+		if err != nil {
+			return fail(err, "dependsOn")
+		}
+
+		task.DependenciesDirty, err = vars.interpolateSlice(task.DependenciesDirty)
+		if err != nil {
+			return fail(err, "dependencies")
+		}
+
+		if task.EnvDirty != nil {
+			env := make(map[string]string, len(task.EnvDirty))
+			for key, value := range task.EnvDirty {
+				env[key], err = vars.interpolate(value)
+				if err != nil {
+					return fail(err, "env")
+				}
+			}
+			task.EnvDirty = env
+		}
+
+		b.BTasks[name] = task
+	}
+
+	for name, run := range b.RTasks {
+		if run == nil {
+			continue
+		}
+		fail := func(err error, field string) error {
+			return fmt.Errorf("%w in `%s` of run `%s`", err, field, name)
+		}
+
+		run.Path, err = vars.interpolate(run.Path)
+		if err != nil {
+			return fail(err, "path")
+		}
+
+		run.DependsOn, err = vars.interpolateSlice(run.DependsOn)
+		if err != nil {
+			return fail(err, "dependsOn")
+		}
+
+		run.DependenciesDirty, err = vars.interpolateSlice(run.DependenciesDirty)
+		if err != nil {
+			return fail(err, "dependencies")
+		}
+	}
+
+	return nil
+}
@@ -3,42 +3,48 @@ package bobfile
 import (
 	"github.com/benchkram/bob/bob/playbook"
 	"github.com/benchkram/bob/bobtask"
+	"github.com/benchkram/bob/pkg/sliceutil"
 )
 
 func (b *Bobfile) Playbook(taskName string, opts ...playbook.Option) (*playbook.Playbook, error) {
+	return b.PlaybookForTasks([]string{taskName}, opts...)
+}
+
+// PlaybookForTasks creates a single playbook for multiple root tasks.
+// Dependencies shared between the roots are build only once.
+func (b *Bobfile) PlaybookForTasks(taskNames []string, opts ...playbook.Option) (*playbook.Playbook, error) {
+	taskNames = sliceutil.Unique(taskNames)
 
-	var idCounter int
 	pb := playbook.New(
-		taskName,
-		idCounter,
+		taskNames,
 		opts...,
 	)
-	idCounter++
 
-	err := b.BTasks.Walk(taskName, "", func(tn string, task bobtask.Task, err error) error {
-		if err != nil {
-			return err
-		}
-		if taskName == tn {
-			// The root task already has an id
+	var idCounter int
+	for _, taskName := range taskNames {
+		err := b.BTasks.Walk(taskName, "", func(tn string, task bobtask.Task, err error) error {
+			if err != nil {
+				return err
+			}
+
+			// a task shared by several roots is added once
+			if _, ok := pb.Tasks[tn]; ok {
+				return nil
+			}
+
+			task.TaskID = idCounter
 			statusTask := playbook.NewStatus(&task)
+
 			pb.Tasks[tn] = statusTask
 			pb.TasksOptimized = append(pb.TasksOptimized, statusTask)
-			return nil
-		}
-
-		task.TaskID = idCounter
-		statusTask := playbook.NewStatus(&task)
-
-		pb.Tasks[tn] = statusTask
-		pb.TasksOptimized = append(pb.TasksOptimized, statusTask)
 
-		idCounter++
+			idCounter++
 
-		return nil
-	})
-	if err != nil {
-		return nil, err
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return pb, nil
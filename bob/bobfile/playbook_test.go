@@ -0,0 +1,78 @@
+package bobfile_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/benchkram/bob/bob/bobfile"
+	"github.com/benchkram/bob/bob/global"
+	"github.com/benchkram/bob/pkg/buildinfostore"
+	"github.com/benchkram/bob/pkg/envutil"
+	"github.com/benchkram/bob/pkg/store/filestore"
+)
+
+func TestPlaybookForTasksSharedDependency(t *testing.T) {
+	dir := t.TempDir()
+	storeDir := t.TempDir()
+
+	content := `build:
+  a:
+    cmd: echo a
+    dependsOn: [shared]
+  b:
+    cmd: echo b
+    dependsOn: [shared]
+  shared:
+    cmd: echo shared >> runs.txt
+    rebuild: always
+`
+	err := os.WriteFile(filepath.Join(dir, global.BobFileName), []byte(content), 0664)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := bobfile.BobfileRead(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buildinfoStore := buildinfostore.New(storeDir)
+	localStore := filestore.New(storeDir)
+	for name, task := range b.BTasks {
+		task.WithEnvStore(envutil.Store{"": []string{}})
+		task.WithBuildinfoStore(buildinfoStore)
+		task.WithLocalstore(localStore)
+		b.BTasks[name] = task
+	}
+
+	pb, err := b.PlaybookForTasks([]string{"a", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(pb.TasksOptimized) != 3 {
+		t.Fatalf("expected 3 tasks, got %d", len(pb.TasksOptimized))
+	}
+	for id, task := range pb.TasksOptimized {
+		if task.TaskID != id {
+			t.Errorf("expected task `%s` to have id %d, got %d", task.Name(), id, task.TaskID)
+		}
+		if pb.Tasks[task.Name()] != task {
+			t.Errorf("expected task `%s` to be the same in Tasks and TasksOptimized", task.Name())
+		}
+	}
+
+	err = pb.Build(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runs, err := os.ReadFile(filepath.Join(dir, "runs.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(runs) != "shared\n" {
+		t.Errorf("expected the shared dependency to run once, got %q", runs)
+	}
+}
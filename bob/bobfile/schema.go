@@ -0,0 +1,171 @@
+package bobfile
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/benchkram/bob/bobrun"
+	"github.com/benchkram/bob/bobtask"
+)
+
+const (
+	schemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+	taskRef = "#/$defs/task"
+	runRef  = "#/$defs/run"
+)
+
+// Schema is a subset of JSON Schema sufficient to describe a Bobfile.
+type Schema struct {
+	Schema string `json:"$schema,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Ref    string `json:"$ref,omitempty"`
+
+	Type string   `json:"type,omitempty"`
+	Enum []string `json:"enum,omitempty"`
+
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	// AdditionalProperties is either false or a *Schema.
+	AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+
+	Items *Schema   `json:"items,omitempty"`
+	OneOf []*Schema `json:"oneOf,omitempty"`
+
+	Defs map[string]*Schema `json:"$defs,omitempty"`
+}
+
+var (
+	stringSchema       = &Schema{Type: "string"}
+	stringListSchema   = &Schema{Type: "array", Items: stringSchema}
+	stringOrListSchema = &Schema{OneOf: []*Schema{stringSchema, stringListSchema}}
+)
+
+// fieldSchemas describes fields whose type does not tell
+// what is accepted, e.g. `interface{}`, keyed by `Type.Field`.
+var fieldSchemas = map[string]*Schema{
+	"Task.TargetDirty": {OneOf: []*Schema{
+		stringSchema,
+		{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"path":  stringSchema,
+				"image": stringSchema,
+			},
+			AdditionalProperties: false,
+		},
+	}},
+	"Task.RebuildDirty": {Type: "string", Enum: []string{
+		string(bobtask.RebuildAlways),
+		string(bobtask.RebuildOnChange),
+	}},
+	"Task.ResourcesDirty": {OneOf: []*Schema{
+		stringListSchema,
+		{Type: "object", AdditionalProperties: &Schema{Type: "integer"}},
+	}},
+	"Task.ShellDirty":   stringOrListSchema,
+	"Task.EnvFileDirty": stringOrListSchema,
+	"Run.ShellDirty":    stringOrListSchema,
+}
+
+// typeSchemas describes types which are read by a custom unmarshaler.
+var typeSchemas = map[reflect.Type]*Schema{
+	reflect.TypeOf(bobtask.Task{}):      {Ref: taskRef},
+	reflect.TypeOf(bobrun.Run{}):        {Ref: runRef},
+	reflect.TypeOf(bobtask.Templates{}): {Type: "object", AdditionalProperties: &Schema{Ref: taskRef}},
+	reflect.TypeOf(bobtask.Matrix{}):    {Type: "object", AdditionalProperties: stringListSchema},
+	reflect.TypeOf(bobrun.RunType("")): {Type: "string", Enum: []string{
+		string(bobrun.RunTypeBinary),
+		string(bobrun.RunTypeCompose),
+	}},
+}
+
+// NewSchema generates the JSON Schema of a bob.yaml
+// from the Bobfile, Task and Run structures.
+func NewSchema() *Schema {
+	task := structSchema(reflect.TypeOf(bobtask.Task{}))
+	// `dependson` is accepted as an alias of `dependsOn`
+	task.Properties["dependson"] = task.Properties["dependsOn"]
+
+	run := structSchema(reflect.TypeOf(bobrun.Run{}))
+	run.Properties["dependson"] = run.Properties["dependsOn"]
+
+	s := structSchema(reflect.TypeOf(Bobfile{}))
+	s.Schema = schemaDraft
+	s.Title = "bob.yaml"
+	s.Defs = map[string]*Schema{
+		"task": task,
+		"run":  run,
+	}
+	return s
+}
+
+func typeSchema(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if s, ok := typeSchemas[t]; ok {
+		return s
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: typeSchema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: typeSchema(t.Elem())}
+	case reflect.Struct:
+		return structSchema(t)
+	default:
+		// anything is accepted
+		return &Schema{}
+	}
+}
+
+// structSchema describes the exported fields of a struct
+// under the keys yaml uses to read them.
+func structSchema(t reflect.Type) *Schema {
+	s := &Schema{
+		Type:                 "object",
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: false,
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if key == "-" {
+			continue
+		}
+		if key == "" {
+			key = strings.ToLower(field.Name)
+		}
+
+		if fs, ok := fieldSchemas[t.Name()+"."+field.Name]; ok {
+			s.Properties[key] = fs
+		} else {
+			s.Properties[key] = typeSchema(field.Type)
+		}
+	}
+
+	return s
+}
+
+// resolve follows a reference into the definitions of root.
+func (s *Schema) resolve(root *Schema) *Schema {
+	for s.Ref != "" {
+		s = root.Defs[strings.TrimPrefix(s.Ref, "#/$defs/")]
+	}
+	return s
+}
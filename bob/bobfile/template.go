@@ -0,0 +1,73 @@
+package bobfile
+
+import (
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/benchkram/bob/bob/global"
+	"github.com/benchkram/bob/bobtask"
+	"github.com/benchkram/bob/pkg/file"
+)
+
+// templates returns the templates available to the tasks of the bobfile.
+// Templates of the bobfile override the ones of its imports.
+func (b *Bobfile) templates() (bobtask.Templates, error) {
+	templates := bobtask.Templates{}
+
+	visited := map[string]bool{b.dir: true}
+	for _, importPath := range b.Imports {
+		err := readTemplates(filepath.Join(b.dir, importPath), templates, visited)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for name, template := range b.Templates {
+		templates[name] = template
+	}
+
+	return templates, nil
+}
+
+// readTemplates adds the templates of the bobfile in dir
+// and of its imports to templates.
+func readTemplates(dir string, templates bobtask.Templates, visited map[string]bool) error {
+	if visited[dir] {
+		return nil
+	}
+	visited[dir] = true
+
+	bobfilePath := filepath.Join(dir, global.BobFileName)
+	if !file.Exists(bobfilePath) {
+		// missing imports are reported when reading the imports
+		return nil
+	}
+	bin, err := os.ReadFile(bobfilePath)
+	if err != nil {
+		return err
+	}
+
+	var partial struct {
+		Imports   []string          `yaml:"import"`
+		Templates bobtask.Templates `yaml:"templates"`
+	}
+	err = yaml.Unmarshal(bin, &partial)
+	if err != nil {
+		return err
+	}
+
+	for _, importPath := range partial.Imports {
+		err = readTemplates(filepath.Join(dir, importPath), templates, visited)
+		if err != nil {
+			return err
+		}
+	}
+
+	for name, template := range partial.Templates {
+		templates[name] = template
+	}
+
+	return nil
+}
@@ -1,6 +1,11 @@
 package bobfile
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/benchkram/bob/pkg/usererror"
 	"github.com/benchkram/errz"
 )
 
@@ -19,10 +24,15 @@ func (b *Bobfile) VerifyAfter() error {
 	return b.verifyAfter()
 }
 
+var ErrCyclicDependency = fmt.Errorf("cyclic dependency")
+
 // verifyBefore verifies a Bobfile before Run() is called.
 func (b *Bobfile) verifyBefore() (err error) {
 	defer errz.Recover(&err)
 
+	err = b.verifyNoCycles()
+	errz.Fatal(err)
+
 	err = b.BTasks.VerifyDuplicateTargets()
 	errz.Fatal(err)
 
@@ -31,9 +41,101 @@ func (b *Bobfile) verifyBefore() (err error) {
 		errz.Fatal(err)
 	}
 
+	err = b.verifyResources()
+	errz.Fatal(err)
+
 	return nil
 }
 
+// verifyNoCycles assures build and run tasks don't depend on themselves
+// through their dependencies. The first cycle found is reported,
+// e.g. `a -> sub/b -> a`.
+func (b *Bobfile) verifyNoCycles() error {
+	dependsOn := make(map[string][]string, len(b.BTasks)+len(b.RTasks))
+	for name, task := range b.BTasks {
+		dependsOn[name] = task.DependsOn
+	}
+	for name, run := range b.RTasks {
+		dependsOn[name] = run.DependsOn
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(dependsOn))
+	stack := []string{}
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			for i, s := range stack {
+				if s == name {
+					return append(append([]string{}, stack[i:]...), name)
+				}
+			}
+		}
+
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, dep := range dependsOn[name] {
+			if _, ok := dependsOn[dep]; !ok {
+				// unknown tasks are reported elsewhere
+				continue
+			}
+			if cycle := visit(dep); cycle != nil {
+				return cycle
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = visited
+
+		return nil
+	}
+
+	// sorted for the same cycle to be reported on every run
+	names := make([]string, 0, len(dependsOn))
+	for name := range dependsOn {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if cycle := visit(name); cycle != nil {
+			return usererror.Wrap(fmt.Errorf("%w: %s", ErrCyclicDependency, strings.Join(cycle, " -> ")))
+		}
+	}
+
+	return nil
+}
+
+// verifyResources assures no task occupies more of
+// a resource than its capacity, which would never run.
+func (b *Bobfile) verifyResources() error {
+	for _, task := range b.BTasks {
+		for name, amount := range task.Resources() {
+			capacity := b.ResourceCapacity(name)
+			if amount > capacity {
+				return usererror.Wrap(fmt.Errorf("%w `%s`: task `%s` occupies %d, but the capacity is %d", ErrInvalidResource, name, task.Name(), amount, capacity))
+			}
+		}
+	}
+	return nil
+}
+
+// ResourceCapacity returns the capacity of a resource,
+// defaults to 1 for resources which are not declared.
+func (b *Bobfile) ResourceCapacity(name string) int {
+	if capacity, ok := b.Resources[name]; ok {
+		return capacity
+	}
+	return 1
+}
+
 // verifyAfter verifies a Bobfile after Run() is called.
 func (b *Bobfile) verifyAfter() (err error) {
 	defer errz.Recover(&err)
@@ -8,14 +8,15 @@ import (
 
 	"github.com/benchkram/bob/bob/bobfile"
 	"github.com/benchkram/bob/bob/playbook"
+	"github.com/benchkram/bob/pkg/boblog"
 )
 
 var (
 	ErrNoRebuildRequired = errors.New("no rebuild required")
 )
 
-// Build a task and it's dependencies.
-func (b *B) Build(ctx context.Context, taskName string) (err error) {
+// Build one or more tasks and their dependencies.
+func (b *B) Build(ctx context.Context, taskNames ...string) (err error) {
 	defer errz.Recover(&err)
 
 	ag, err := b.Aggregate()
@@ -23,14 +24,55 @@ func (b *B) Build(ctx context.Context, taskName string) (err error) {
 
 	b.PrintVersionCompatibility(ag)
 
-	err = b.nix.BuildNixDependenciesInPipeline(ag, taskName)
+	err = b.nix.BuildNixDependenciesInPipeline(ag, taskNames...)
 	errz.Fatal(err)
 
 	// Hint: Hash computation (playbook execution) can only start after
 	// nix dependencies are resolved.
 	// Nix dependencies are considered in the input hash of a task.
-	p, err := ag.Playbook(
-		taskName,
+	_, err = b.buildPlaybook(ctx, ag, taskNames)
+	errz.Fatal(err)
+
+	return nil
+}
+
+// buildPlaybook creates a playbook for the tasks of an aggregate and builds it.
+// The playbook is returned even if the build failed.
+func (b *B) buildPlaybook(ctx context.Context, ag *bobfile.Bobfile, taskNames []string) (_ *playbook.Playbook, err error) {
+	p, err := ag.PlaybookForTasks(taskNames, b.playbookOptions(ag)...)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.Build(ctx)
+	if errr := b.recordHistory(p, taskNames, err); errr != nil {
+		boblog.Log.Error(errr, "failed to record build history")
+	}
+
+	return p, err
+}
+
+// Explain computes the rebuild decision for one or more tasks and their
+// dependencies without running any of them.
+func (b *B) Explain(ctx context.Context, taskNames ...string) (_ []*playbook.Explanation, err error) {
+	defer errz.Recover(&err)
+
+	ag, err := b.Aggregate()
+	errz.Fatal(err)
+
+	// Nix dependencies are considered in the input hash of a task.
+	err = b.nix.BuildNixDependenciesInPipeline(ag, taskNames...)
+	errz.Fatal(err)
+
+	p, err := ag.PlaybookForTasks(taskNames, b.playbookOptions(ag)...)
+	errz.Fatal(err)
+
+	return p.Explain(ctx)
+}
+
+// playbookOptions returns the options to create a playbook from an aggregate.
+func (b *B) playbookOptions(ag *bobfile.Bobfile) []playbook.Option {
+	return []playbook.Option{
 		playbook.WithCachingEnabled(b.enableCaching),
 		playbook.WithPredictedNumOfTasks(len(ag.BTasks)),
 		playbook.WithMaxParallel(b.maxParallel),
@@ -38,13 +80,12 @@ func (b *B) Build(ctx context.Context, taskName string) (err error) {
 		playbook.WithLocalStore(b.local),
 		playbook.WithPushEnabled(b.enablePush),
 		playbook.WithPullEnabled(b.enablePull),
-	)
-	errz.Fatal(err)
-
-	err = p.Build(ctx)
-	errz.Fatal(err)
-
-	return nil
+		playbook.WithKeepGoing(b.keepGoing),
+		playbook.WithReplayOutput(b.replayOutput),
+		playbook.WithEventHandler(b.eventHandler),
+		playbook.WithTraceFile(b.traceFile),
+		playbook.WithResources(ag.Resources),
+	}
 }
 
 // AggregateWithNixDeps does aggregation together with evaluating nix dependecies.
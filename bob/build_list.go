@@ -21,3 +21,23 @@ func (b *B) GetBuildTasks() (tasks []string, err error) {
 
 	return keys, nil
 }
+
+// GetDisabledBuildTasks returns the names of build tasks
+// disabled by their `when` condition.
+func (b *B) GetDisabledBuildTasks() (tasks []string, err error) {
+	defer errz.Recover(&err)
+
+	omitRunTasks := true
+	aggregate, err := b.AggregateSparse(omitRunTasks)
+	errz.Fatal(err)
+
+	tasks = []string{}
+	for _, task := range aggregate.BTasks {
+		if task.Disabled() {
+			tasks = append(tasks, task.Name())
+		}
+	}
+	sort.Strings(tasks)
+
+	return tasks, nil
+}
@@ -0,0 +1,51 @@
+package bob
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/benchkram/errz"
+
+	"github.com/benchkram/bob/bob/bobfile"
+	"github.com/benchkram/bob/pkg/usererror"
+)
+
+// Format rewrites the bob.yaml in the current directory and all
+// imported ones into their canonical form, see bobfile.Format.
+// Files which were not formatted are returned. With check
+// set the files are left untouched.
+func (b *B) Format(check bool) (unformatted []string, err error) {
+	defer errz.Recover(&err)
+
+	files, err := bobfile.Files(".")
+	if errors.Is(err, bobfile.ErrBobfileNotFound) {
+		return nil, usererror.Wrap(ErrCouldNotFindTopLevelBobfile)
+	}
+	errz.Fatal(err)
+
+	for _, f := range files {
+		bin, err := os.ReadFile(f)
+		errz.Fatal(err)
+
+		formatted, err := bobfile.Format(bin)
+		if err != nil {
+			return nil, usererror.Wrapm(err, fmt.Sprintf("failed to format %s", f))
+		}
+
+		if bytes.Equal(bin, formatted) {
+			continue
+		}
+		unformatted = append(unformatted, f)
+
+		if !check {
+			info, err := os.Stat(f)
+			errz.Fatal(err)
+			err = os.WriteFile(f, formatted, info.Mode())
+			errz.Fatal(err)
+		}
+	}
+
+	return unformatted, nil
+}
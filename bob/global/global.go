@@ -18,6 +18,8 @@ var (
 	BobCacheTaskHashesFileName = filepath.Join(BobCacheDir, "hashes")
 	BobCacheArtifactsDir       = filepath.Join(BobCacheDir, "artifacts")
 	BobAuthStoreDir            = filepath.Join(BobCacheDir, "auth")
+	BobCacheHistoryDir         = filepath.Join(BobCacheDir, "history")
+	BobCacheLogsDir            = filepath.Join(BobCacheDir, "logs")
 
 	BobCacheNixFileName      = filepath.Join(BobCacheDir, BobNixCacheFile)
 	BobCacheNixShellCacheDir = filepath.Join(BobCacheDir, "env")
@@ -0,0 +1,273 @@
+package bob
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/benchkram/errz"
+
+	"github.com/benchkram/bob/bob/playbook"
+	"github.com/benchkram/bob/bobtask/targettype"
+	"github.com/benchkram/bob/pkg/boberror"
+	"github.com/benchkram/bob/pkg/usererror"
+)
+
+const (
+	GraphNodeBuild = "build"
+	GraphNodeRun   = "run"
+)
+
+// Graph is the graph of build and run tasks and their dependencies.
+type Graph struct {
+	Nodes []*GraphNode `json:"nodes"`
+}
+
+// GraphNode is a build or run task.
+type GraphNode struct {
+	Name string `json:"name"`
+	// Kind is either `build` or `run`.
+	Kind      string   `json:"kind"`
+	DependsOn []string `json:"dependsOn"`
+
+	// Inputs is the number of input files of a build task.
+	Inputs int `json:"inputs"`
+	// Target is the target type of a build task, empty without target.
+	Target targettype.T `json:"target,omitempty"`
+	// Dependencies are the nix dependencies.
+	Dependencies []string `json:"dependencies,omitempty"`
+
+	Disabled bool `json:"disabled,omitempty"`
+	// Status is the rebuild decision of a build task,
+	// only set when requested.
+	Status playbook.Decision `json:"status,omitempty"`
+}
+
+// Graph returns the graph of all build and run tasks or of taskName and
+// the tasks it depends on. With status set the rebuild decision of each
+// build task is computed, see Explain.
+func (b *B) Graph(ctx context.Context, taskName string, status bool) (_ *Graph, err error) {
+	defer errz.Recover(&err)
+
+	ag, err := b.Aggregate()
+	errz.Fatal(err)
+
+	nodes := map[string]*GraphNode{}
+	for _, task := range ag.BTasks {
+		node := &GraphNode{
+			Name:      task.Name(),
+			Kind:      GraphNodeBuild,
+			DependsOn: task.DependsOn,
+			Inputs:    len(task.Inputs()),
+			Disabled:  task.Disabled(),
+		}
+
+		target, err := task.Target()
+		errz.Fatal(err)
+		if target != nil {
+			if len(target.DockerImages()) > 0 {
+				node.Target = targettype.Docker
+			} else {
+				node.Target = targettype.Path
+			}
+		}
+
+		for _, d := range task.Dependencies() {
+			node.Dependencies = append(node.Dependencies, d.Name)
+		}
+
+		nodes[node.Name] = node
+	}
+	for _, run := range ag.RTasks {
+		node := &GraphNode{
+			Name:      run.Name(),
+			Kind:      GraphNodeRun,
+			DependsOn: run.DependsOn,
+		}
+		for _, d := range run.Dependencies() {
+			node.Dependencies = append(node.Dependencies, d.Name)
+		}
+		nodes[node.Name] = node
+	}
+
+	if taskName != "" {
+		if _, ok := nodes[taskName]; !ok {
+			return nil, usererror.Wrap(boberror.ErrTaskDoesNotExistF(taskName))
+		}
+		nodes = reachable(nodes, taskName)
+	}
+
+	g := &Graph{Nodes: make([]*GraphNode, 0, len(nodes))}
+	for _, node := range nodes {
+		// drop edges to unknown tasks
+		dependsOn := []string{}
+		for _, dep := range node.DependsOn {
+			if _, ok := nodes[dep]; ok {
+				dependsOn = append(dependsOn, dep)
+			}
+		}
+		sort.Strings(dependsOn)
+		node.DependsOn = dependsOn
+
+		g.Nodes = append(g.Nodes, node)
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		return g.Nodes[i].Name < g.Nodes[j].Name
+	})
+
+	if status {
+		buildTasks := []string{}
+		for _, node := range g.Nodes {
+			if node.Kind == GraphNodeBuild {
+				buildTasks = append(buildTasks, node.Name)
+			}
+		}
+
+		if len(buildTasks) > 0 {
+			explanations, err := b.Explain(ctx, buildTasks...)
+			errz.Fatal(err)
+
+			for _, e := range explanations {
+				if node, ok := nodes[e.TaskName]; ok {
+					node.Status = e.Decision
+				}
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// reachable returns the nodes reachable from name.
+func reachable(nodes map[string]*GraphNode, name string) map[string]*GraphNode {
+	result := map[string]*GraphNode{}
+
+	var visit func(name string)
+	visit = func(name string) {
+		node, ok := nodes[name]
+		if !ok {
+			return
+		}
+		if _, ok := result[name]; ok {
+			return
+		}
+		result[name] = node
+		for _, dep := range node.DependsOn {
+			visit(dep)
+		}
+	}
+	visit(name)
+
+	return result
+}
+
+// labels returns the lines describing a node, details adds
+// input count, target type and nix dependencies.
+func (n *GraphNode) labels(details bool) []string {
+	labels := []string{n.Name}
+	if n.Kind == GraphNodeRun {
+		labels[0] += " (run)"
+	}
+	if n.Disabled {
+		labels = append(labels, "disabled")
+	}
+	if n.Status != "" {
+		labels = append(labels, "status: "+string(n.Status))
+	}
+
+	if details {
+		if n.Kind == GraphNodeBuild {
+			labels = append(labels, fmt.Sprintf("inputs: %d", n.Inputs))
+		}
+		if n.Target != "" {
+			labels = append(labels, "target: "+string(n.Target))
+		}
+		if len(n.Dependencies) > 0 {
+			labels = append(labels, "nix: "+strings.Join(n.Dependencies, ", "))
+		}
+	}
+
+	return labels
+}
+
+// statusColors are the colors used to show rebuild decisions.
+var statusColors = map[playbook.Decision]string{
+	playbook.DecisionRebuild:         "orange",
+	playbook.DecisionCached:          "green",
+	playbook.DecisionExtractArtifact: "lightblue",
+	playbook.DecisionPullArtifact:    "lightblue",
+	playbook.DecisionDisabled:        "gray",
+}
+
+// DOT renders the graph in the Graphviz dot language,
+// edges point from a task to its dependencies.
+func (g *Graph) DOT(details bool) string {
+	var sb strings.Builder
+	sb.WriteString("digraph bob {\n")
+	sb.WriteString("  node [shape=box];\n")
+
+	for _, node := range g.Nodes {
+		attrs := []string{fmt.Sprintf("label=%q", strings.Join(node.labels(details), "\n"))}
+		if node.Kind == GraphNodeRun {
+			attrs = append(attrs, "shape=ellipse")
+		}
+		if color, ok := statusColors[node.Status]; ok {
+			attrs = append(attrs, "style=filled", fmt.Sprintf("fillcolor=%q", color))
+		} else if node.Disabled {
+			attrs = append(attrs, "style=dashed")
+		}
+		sb.WriteString(fmt.Sprintf("  %q [%s];\n", node.Name, strings.Join(attrs, ", ")))
+	}
+
+	for _, node := range g.Nodes {
+		for _, dep := range node.DependsOn {
+			sb.WriteString(fmt.Sprintf("  %q -> %q;\n", node.Name, dep))
+		}
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// Mermaid renders the graph as a Mermaid flowchart,
+// edges point from a task to its dependencies.
+func (g *Graph) Mermaid(details bool) string {
+	// task names can't be used as ids as they may contain `/` or `[`
+	ids := make(map[string]string, len(g.Nodes))
+	for i, node := range g.Nodes {
+		ids[node.Name] = fmt.Sprintf("n%d", i)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("flowchart TD\n")
+
+	for _, node := range g.Nodes {
+		label := strings.ReplaceAll(strings.Join(node.labels(details), "<br/>"), `"`, "#quot;")
+		if node.Kind == GraphNodeRun {
+			sb.WriteString(fmt.Sprintf("  %s([\"%s\"])\n", ids[node.Name], label))
+		} else {
+			sb.WriteString(fmt.Sprintf("  %s[\"%s\"]\n", ids[node.Name], label))
+		}
+	}
+
+	for _, node := range g.Nodes {
+		for _, dep := range node.DependsOn {
+			sb.WriteString(fmt.Sprintf("  %s --> %s\n", ids[node.Name], ids[dep]))
+		}
+	}
+
+	for _, node := range g.Nodes {
+		if color, ok := statusColors[node.Status]; ok {
+			sb.WriteString(fmt.Sprintf("  style %s fill:%s\n", ids[node.Name], color))
+		}
+	}
+
+	return sb.String()
+}
+
+// JSON renders the graph as indented JSON.
+func (g *Graph) JSON() ([]byte, error) {
+	return json.MarshalIndent(g, "", "  ")
+}
@@ -0,0 +1,44 @@
+package bob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/benchkram/bob/bob/playbook"
+	"github.com/benchkram/bob/bobtask/targettype"
+)
+
+func TestGraphRender(t *testing.T) {
+	nodes := map[string]*GraphNode{
+		"build":     {Name: "build", Kind: GraphNodeBuild, DependsOn: []string{"sub/gen"}, Inputs: 2, Target: targettype.Path, Status: playbook.DecisionCached},
+		"sub/gen":   {Name: "sub/gen", Kind: GraphNodeBuild},
+		"server":    {Name: "server", Kind: GraphNodeRun, DependsOn: []string{"build"}},
+		"unrelated": {Name: "unrelated", Kind: GraphNodeBuild},
+	}
+
+	reached := reachable(nodes, "server")
+	assert.Len(t, reached, 3)
+	assert.NotContains(t, reached, "unrelated")
+
+	g := &Graph{Nodes: []*GraphNode{nodes["build"], nodes["server"], nodes["sub/gen"]}}
+
+	assert.Equal(t, `digraph bob {
+  node [shape=box];
+  "build" [label="build\nstatus: cached\ninputs: 2\ntarget: path", style=filled, fillcolor="green"];
+  "server" [label="server (run)", shape=ellipse];
+  "sub/gen" [label="sub/gen\ninputs: 0"];
+  "build" -> "sub/gen";
+  "server" -> "build";
+}
+`, g.DOT(true))
+
+	assert.Equal(t, `flowchart TD
+  n0["build<br/>status: cached"]
+  n1(["server (run)"])
+  n2["sub/gen"]
+  n0 --> n2
+  n1 --> n0
+  style n0 fill:green
+`, g.Mermaid(false))
+}
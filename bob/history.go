@@ -0,0 +1,75 @@
+package bob
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sort"
+
+	"github.com/benchkram/errz"
+
+	"github.com/benchkram/bob/bob/playbook"
+	"github.com/benchkram/bob/pkg/history"
+)
+
+// recordHistory stores the outcome of a playbook run in the history store.
+func (b *B) recordHistory(p *playbook.Playbook, taskNames []string, buildErr error) (err error) {
+	defer errz.Recover(&err)
+
+	if b.historyStore == nil {
+		return nil
+	}
+
+	wd, err := os.Getwd()
+	errz.Fatal(err)
+
+	run := &history.Run{
+		Dir:      wd,
+		Roots:    taskNames,
+		Start:    p.Start(),
+		Duration: p.ExecutionTime(),
+		Status:   history.StatusSuccess,
+		Tasks:    []history.Task{},
+	}
+
+	if buildErr != nil {
+		run.Status = history.StatusFailed
+		if errors.Is(buildErr, context.Canceled) {
+			run.Status = history.StatusCanceled
+		}
+		run.Error = buildErr.Error()
+	}
+
+	for name, t := range p.Tasks {
+		task := history.Task{
+			Name:  name,
+			State: string(t.State()),
+		}
+
+		inputHash, cause := t.RebuildInfo()
+		task.InputHash = inputHash.String()
+		task.Cause = string(cause)
+
+		switch t.State() {
+		case playbook.StateCompleted, playbook.StateNoRebuildRequired, playbook.StateFailed, playbook.StateCanceled:
+			task.Duration = t.ExecutionTime()
+		}
+
+		run.Tasks = append(run.Tasks, task)
+	}
+	sort.Slice(run.Tasks, func(i, j int) bool {
+		return run.Tasks[i].Name < run.Tasks[j].Name
+	})
+
+	return b.historyStore.Add(run)
+}
+
+// History returns all recorded playbook runs, oldest first.
+func (b *B) History() ([]*history.Run, error) {
+	return b.historyStore.Runs()
+}
+
+// HistoryRun returns a single recorded playbook run.
+func (b *B) HistoryRun(id int) (*history.Run, error) {
+	return b.historyStore.Run(id)
+}
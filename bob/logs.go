@@ -0,0 +1,25 @@
+package bob
+
+import (
+	"errors"
+
+	"github.com/benchkram/bob/pkg/logstore"
+	"github.com/benchkram/bob/pkg/usererror"
+)
+
+// TaskLog returns the path to the log of a build task for the given
+// input hash. The most recent log is returned for an empty hash.
+func (b *B) TaskLog(taskName, hash string) (path string, err error) {
+	logStore := b.logStore.Project(b.dir)
+	if hash == "" {
+		path, err = logStore.Latest(taskName)
+	} else {
+		path, err = logStore.Path(taskName, hash)
+	}
+	if errors.Is(err, logstore.ErrLogNotFound) ||
+		errors.Is(err, logstore.ErrInvalidTaskName) ||
+		errors.Is(err, logstore.ErrInvalidHash) {
+		return "", usererror.Wrap(err)
+	}
+	return path, err
+}
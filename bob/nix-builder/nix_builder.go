@@ -8,6 +8,7 @@ import (
 
 	"github.com/benchkram/bob/bob/bobfile"
 	"github.com/benchkram/bob/pkg/nix"
+	"github.com/benchkram/bob/pkg/sliceutil"
 	"github.com/benchkram/bob/pkg/usererror"
 )
 
@@ -65,18 +66,22 @@ func (n *NB) EnvStore() envutil.Store {
 	return n.envStore
 }
 
-// BuildNixDependenciesInPipeline collects and builds nix-dependencies for a pipeline starting at taskName.
-func (n *NB) BuildNixDependenciesInPipeline(ag *bobfile.Bobfile, taskName string) (err error) {
+// BuildNixDependenciesInPipeline collects and builds nix-dependencies for the pipelines starting at taskNames.
+func (n *NB) BuildNixDependenciesInPipeline(ag *bobfile.Bobfile, taskNames ...string) (err error) {
 	defer errz.Recover(&err)
 
 	if !nix.IsInstalled() {
 		return usererror.Wrap(fmt.Errorf("nix is not installed on your system. Get it from %s", nix.DownloadURl()))
 	}
 
-	tasksInPipeline, err := ag.BTasks.CollectTasksInPipeline(taskName)
-	errz.Fatal(err)
+	var tasksInPipeline []string
+	for _, taskName := range taskNames {
+		tasks, err := ag.BTasks.CollectTasksInPipeline(taskName)
+		errz.Fatal(err)
+		tasksInPipeline = append(tasksInPipeline, tasks...)
+	}
 
-	return n.BuildNixDependencies(ag, tasksInPipeline, []string{})
+	return n.BuildNixDependencies(ag, sliceutil.Unique(tasksInPipeline), []string{})
 }
 
 // BuildNixDependencies builds nix dependencies and prepares the affected tasks
@@ -93,6 +98,12 @@ func (n *NB) BuildNixDependencies(ag *bobfile.Bobfile, buildTasksInPipeline, run
 	for _, name := range buildTasksInPipeline {
 		t := ag.BTasks[name]
 
+		// disabled tasks never run, their dependencies
+		// might not even be available on this platform.
+		if t.Disabled() {
+			continue
+		}
+
 		// construct used dependencies for this task
 		var deps []nix.Dependency
 		deps = append(deps, t.Dependencies()...)
@@ -1,9 +1,14 @@
 package bob
 
 import (
+	"time"
+
 	nixbuilder "github.com/benchkram/bob/bob/nix-builder"
+	"github.com/benchkram/bob/bob/playbook"
 	"github.com/benchkram/bob/pkg/auth"
 	"github.com/benchkram/bob/pkg/buildinfostore"
+	"github.com/benchkram/bob/pkg/history"
+	"github.com/benchkram/bob/pkg/logstore"
 	"github.com/benchkram/bob/pkg/store"
 )
 
@@ -35,6 +40,18 @@ func WithAuthStore(store *auth.Store) Option {
 	}
 }
 
+func WithHistoryStore(store *history.Store) Option {
+	return func(b *B) {
+		b.historyStore = store
+	}
+}
+
+func WithLogStore(store *logstore.Store) Option {
+	return func(b *B) {
+		b.logStore = store
+	}
+}
+
 func WithRemotestore(store store.Store) Option {
 	return func(b *B) {
 		b.remote = store
@@ -88,3 +105,39 @@ func WithMaxParallel(maxParallel int) Option {
 		b.maxParallel = maxParallel
 	}
 }
+
+func WithKeepGoing(keepGoing bool) Option {
+	return func(b *B) {
+		b.keepGoing = keepGoing
+	}
+}
+
+func WithReplayOutput(replay bool) Option {
+	return func(b *B) {
+		b.replayOutput = replay
+	}
+}
+
+func WithTaskTimeout(timeout time.Duration) Option {
+	return func(b *B) {
+		b.taskTimeout = timeout
+	}
+}
+
+func WithKillTimeout(timeout time.Duration) Option {
+	return func(b *B) {
+		b.killTimeout = timeout
+	}
+}
+
+func WithEventHandler(handler playbook.EventHandler) Option {
+	return func(b *B) {
+		b.eventHandler = handler
+	}
+}
+
+func WithTraceFile(path string) Option {
+	return func(b *B) {
+		b.traceFile = path
+	}
+}
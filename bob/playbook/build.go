@@ -22,6 +22,16 @@ func (p *Playbook) Build(ctx context.Context) (err error) {
 	workers := p.maxParallel
 	boblog.Log.Info(fmt.Sprintf("Using %d workers", workers))
 
+	if p.traceFile != "" {
+		p.tracer = newTracer()
+		defer func() {
+			errr := p.tracer.writeFile(p.traceFile, workers)
+			if errr != nil && err == nil {
+				err = usererror.Wrapm(errr, "failed to write trace file")
+			}
+		}()
+	}
+
 	p.pickTaskColors()
 
 	wm := p.startWorkers(ctx, workers)
@@ -91,6 +101,11 @@ func (p *Playbook) Build(ctx context.Context) (err error) {
 	p.summary(wm.processed)
 
 	if len(wm.errors) > 0 {
+		// In keep-going mode all failures are listed in the summary.
+		if p.keepGoing && len(wm.errors) > 1 {
+			return usererror.Wrap(fmt.Errorf("%w: %d tasks failed", ErrFailed, len(wm.errors)))
+		}
+
 		// Pass only the very first processing error.
 		return wm.errors[0]
 	}
@@ -14,8 +14,6 @@ import (
 
 // build a single task and update the playbook state after completion.
 func (p *Playbook) build(ctx context.Context, task *bobtask.Task) (pt *processed.Task, err error) {
-	defer errz.Recover(&err)
-
 	// if `pt` is `nil` errz.Fatal()
 	// returns a nil task which could lead
 	// to memory leaks down the line.
@@ -23,34 +21,51 @@ func (p *Playbook) build(ctx context.Context, task *bobtask.Task) (pt *processed
 
 	// A task is flagged successful before
 	var taskSuccessFul bool
-	var taskErr error
+	coloredName := task.ColoredName()
+
+	// Deferred before errz.Recover() to see the recovered error.
 	defer func() {
-		if !taskSuccessFul {
-			errr := p.TaskFailed(task.TaskID, taskErr)
+		if taskSuccessFul {
+			return
+		}
+		// A canceled task is flagged as such once it has terminated.
+		if errors.Is(ctx.Err(), context.Canceled) {
+			boblog.Log.V(1).Info(fmt.Sprintf("%-*s\t%s", p.namePad, coloredName, StateCanceled))
+			errr := p.TaskCanceled(task.TaskID)
 			if errr != nil {
-				boblog.Log.Error(errr, "Setting the task state to failed, failed.")
+				boblog.Log.Error(errr, "Setting the task state to canceled, failed.")
 			}
+			return
+		}
+		errr := p.TaskFailed(task.TaskID, err)
+		if errr != nil {
+			boblog.Log.Error(errr, "Setting the task state to failed, failed.")
 		}
 	}()
+	defer errz.Recover(&err)
 
-	coloredName := task.ColoredName()
+	ts := p.TasksOptimized[task.TaskID]
+	defer p.traceSpan(ts, SpanTask)()
 
-	done := make(chan struct{})
-	defer close(done)
+	// A disabled task is a no-op satisfying its dependents.
+	if task.Disabled() {
+		status := StateDisabled
+		boblog.Log.V(2).Info(fmt.Sprintf("%-*s\t%s", p.namePad, coloredName, status.Short()))
+		taskSuccessFul = true
+		return pt, p.TaskDisabled(task.TaskID)
+	}
 
-	go func() {
-		select {
-		case <-done:
-		case <-ctx.Done():
-			if errors.Is(ctx.Err(), context.Canceled) {
-				boblog.Log.V(1).Info(fmt.Sprintf("%-*s\t%s", p.namePad, coloredName, StateCanceled))
-				_ = p.TaskCanceled(task.TaskID)
-			}
-		}
-	}()
+	endSpan := p.traceSpan(ts, SpanHash)
+	hashIn, err := task.HashIn()
+	endSpan()
+	errz.Fatal(err)
 
+	endSpan = p.traceSpan(ts, SpanRebuildCheck)
 	rebuild, err := p.TaskNeedsRebuild(task.TaskID)
+	endSpan()
 	errz.Fatal(err)
+
+	ts.SetRebuildInfo(hashIn, rebuild.Cause)
 	boblog.Log.V(2).Info(fmt.Sprintf("TaskNeedsRebuild [rebuildRequired: %t] [cause:%s]", rebuild.IsRequired, rebuild.Cause))
 
 	// Task might need a rebuild due to an input change.
@@ -59,20 +74,26 @@ func (p *Playbook) build(ctx context.Context, task *bobtask.Task) (pt *processed
 	if rebuild.IsRequired {
 		switch rebuild.Cause {
 		case InputNotFoundInBuildInfo:
-			hashIn, err := task.HashIn()
-			errz.Fatal(err)
-
 			// pull artifact if it exists on the remote. if exists locally will use that one
+			endSpan = p.traceSpan(ts, SpanArtifactPull)
 			err = p.pullArtifact(ctx, hashIn, task, false)
+			endSpan()
 			errz.Fatal(err)
 
+			endSpan = p.traceSpan(ts, SpanArtifactExtract)
 			success, err := task.ArtifactExtract(hashIn, rebuild.VerifyResult.InvalidFiles)
+			endSpan()
 			if err != nil {
 				// if local artifact is corrupted due to incomplete previous download, try a fresh download
 				if errors.Is(err, io.ErrUnexpectedEOF) {
+					endSpan = p.traceSpan(ts, SpanArtifactPull)
 					err = p.pullArtifact(ctx, hashIn, task, true)
+					endSpan()
 					errz.Fatal(err)
+
+					endSpan = p.traceSpan(ts, SpanArtifactExtract)
 					success, err = task.ArtifactExtract(hashIn, rebuild.VerifyResult.InvalidFiles)
+					endSpan()
 				}
 			}
 
@@ -90,9 +111,9 @@ func (p *Playbook) build(ctx context.Context, task *bobtask.Task) (pt *processed
 			}
 		case TargetInvalid:
 			boblog.Log.V(2).Info(fmt.Sprintf("%-*s\t%s, extracting artifact", p.namePad, coloredName, rebuild.Cause))
-			hashIn, err := task.HashIn()
-			errz.Fatal(err)
+			endSpan = p.traceSpan(ts, SpanArtifactExtract)
 			success, err := task.ArtifactExtract(hashIn, rebuild.VerifyResult.InvalidFiles)
+			endSpan()
 			errz.Fatal(err)
 			if success {
 				rebuild.IsRequired = false
@@ -107,6 +128,12 @@ func (p *Playbook) build(ctx context.Context, task *bobtask.Task) (pt *processed
 	if !rebuild.IsRequired {
 		status := StateNoRebuildRequired
 		boblog.Log.V(2).Info(fmt.Sprintf("%-*s\t%s", p.namePad, coloredName, status.Short()))
+		if p.replayOutput {
+			err = task.ReplayOutput(p.namePad)
+			if err != nil {
+				boblog.Log.Error(err, fmt.Sprintf("failed to replay output of task %s", task.Name()))
+			}
+		}
 		taskSuccessFul = true
 		return pt, p.TaskNoRebuildRequired(task.TaskID)
 	}
@@ -114,13 +141,17 @@ func (p *Playbook) build(ctx context.Context, task *bobtask.Task) (pt *processed
 	err = task.CleanTargetsWithReason(rebuild.VerifyResult.InvalidFiles)
 	errz.Fatal(err)
 
-	err = task.Run(ctx, p.namePad)
-	if err != nil {
-		taskSuccessFul = false
-		taskErr = err
-	}
+	endSpan = p.traceSpan(ts, SpanRun)
+	err = p.runWithRetries(ctx, task)
+	endSpan()
 	errz.Fatal(err)
 
+	// The targets of a task canceled at the end of its run might
+	// be incomplete, they must not end up in an artifact or buildinfo.
+	if ctx.Err() != nil {
+		return pt, ctx.Err()
+	}
+
 	// FIXME: Is this placed correctly?
 	// Could also be done after the task completion is
 	// done (artifact validation & packaging).
@@ -3,6 +3,7 @@ package playbook
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/benchkram/bob/pkg/boblog"
 	"github.com/logrusorgru/aurora"
@@ -48,7 +49,16 @@ func (p *Playbook) pickTaskColors() {
 	}
 	p.namePad += 14
 
-	dependencies := len(tasks) - 1
-	rootName := p.Tasks[p.root].ColoredName()
-	boblog.Log.V(1).Info(fmt.Sprintf("Running task %s with %d dependencies", rootName, dependencies))
+	rootNames := make([]string, 0, len(p.roots))
+	for _, root := range p.roots {
+		rootNames = append(rootNames, p.Tasks[root].ColoredName())
+	}
+
+	// Roots might depend on each other, the number
+	// of dependencies is only shown for a single root.
+	if len(rootNames) == 1 {
+		boblog.Log.V(1).Info(fmt.Sprintf("Running task %s with %d dependencies", rootNames[0], len(tasks)-1))
+	} else {
+		boblog.Log.V(1).Info(fmt.Sprintf("Running tasks %s", strings.Join(rootNames, ", ")))
+	}
 }
@@ -0,0 +1,35 @@
+package playbook
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/benchkram/bob/bobtask"
+	"github.com/benchkram/bob/pkg/boblog"
+)
+
+func TestPickTaskColorsRunningMessage(t *testing.T) {
+	tasks := func() bobtask.Map {
+		return bobtask.Map{
+			"build": bobtask.Task{DependsOn: []string{"gen", "lint"}},
+			"gen":   bobtask.Task{},
+			"lint":  bobtask.Task{},
+		}
+	}
+
+	var buf bytes.Buffer
+	boblog.SetOutput(&buf)
+	defer boblog.SetOutput(nil)
+
+	p := newTestPlaybook(t, tasks(), []string{"build"})
+	p.pickTaskColors()
+	assert.Contains(t, buf.String(), "with 2 dependencies")
+
+	// gen is a root and a dependency of build
+	buf.Reset()
+	p = newTestPlaybook(t, tasks(), []string{"build", "gen"})
+	p.pickTaskColors()
+	assert.NotContains(t, buf.String(), "dependencies")
+}
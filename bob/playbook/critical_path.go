@@ -0,0 +1,83 @@
+package playbook
+
+import (
+	"time"
+)
+
+// criticalPath returns the longest dependency chain of the playbook,
+// weighted by the execution time of each task. The chain is ordered
+// from the deepest dependency up to the root task.
+func (p *Playbook) criticalPath() (path []*Status, duration time.Duration) {
+	p.prepareOptimizedAccess()
+
+	type longest struct {
+		duration time.Duration
+		// next is the id of the dependency on the longest chain, -1 if none.
+		next int
+	}
+	memo := make(map[int]longest, len(p.TasksOptimized))
+
+	var visit func(taskID int) time.Duration
+	visit = func(taskID int) time.Duration {
+		if l, ok := memo[taskID]; ok {
+			return l.duration
+		}
+
+		task := p.TasksOptimized[taskID]
+		l := longest{next: -1}
+		for _, id := range task.DependsOnIDs {
+			d := visit(id)
+			if l.next == -1 || d > l.duration {
+				l.duration = d
+				l.next = id
+			}
+		}
+		l.duration += taskDuration(task)
+
+		memo[taskID] = l
+		return l.duration
+	}
+	rootID := -1
+	for _, id := range p.rootIDs {
+		if d := visit(id); rootID == -1 || d > duration {
+			duration = d
+			rootID = id
+		}
+	}
+
+	for id := rootID; id != -1; id = memo[id].next {
+		path = append([]*Status{p.TasksOptimized[id]}, path...)
+	}
+
+	return path, duration
+}
+
+// parallelism returns the ratio of the accumulated execution
+// time of all tasks to the wall-clock time of the playbook.
+func (p *Playbook) parallelism() float64 {
+	wallClock := p.ExecutionTime()
+	if wallClock <= 0 {
+		return 0
+	}
+
+	var total time.Duration
+	for _, t := range p.Tasks {
+		total += taskDuration(t)
+	}
+
+	return float64(total) / float64(wallClock)
+}
+
+// taskDuration returns the execution time of a task,
+// zero for tasks which have not been processed.
+func taskDuration(task *Status) time.Duration {
+	switch task.State() {
+	case StateCompleted, StateNoRebuildRequired, StateFailed, StateCanceled:
+	default:
+		return 0
+	}
+	if task.End().IsZero() {
+		return 0
+	}
+	return task.ExecutionTime()
+}
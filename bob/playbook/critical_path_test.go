@@ -0,0 +1,48 @@
+package playbook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/benchkram/bob/bobtask"
+)
+
+func TestCriticalPath(t *testing.T) {
+	p := newTestPlaybook(t, bobtask.Map{
+		"all":      bobtask.Task{DependsOn: []string{"build", "lint", "docs"}},
+		"build":    bobtask.Task{DependsOn: []string{"generate"}},
+		"generate": bobtask.Task{},
+		"lint":     bobtask.Task{},
+		"docs":     bobtask.Task{},
+	}, []string{"all"})
+
+	start := time.Now().Add(-time.Hour)
+	p.start = start
+	durations := map[string]time.Duration{
+		"all":      1 * time.Minute,
+		"build":    3 * time.Minute,
+		"generate": 2 * time.Minute,
+		"lint":     4 * time.Minute,
+	}
+	for name, d := range durations {
+		task := p.Tasks[name]
+		task.SetState(StateCompleted, nil)
+		task.SetStart(start)
+		task.SetEnd(start.Add(d))
+	}
+	// docs hasn't been processed and doesn't count
+	p.Tasks["docs"].SetStart(start)
+
+	path, duration := p.criticalPath()
+	names := []string{}
+	for _, task := range path {
+		names = append(names, task.Name())
+	}
+	assert.Equal(t, []string{"generate", "build", "all"}, names)
+	assert.Equal(t, 6*time.Minute, duration)
+
+	// 10 minutes of work in about an hour
+	assert.InDelta(t, 1.0/6, p.parallelism(), 0.001)
+}
@@ -0,0 +1,37 @@
+package playbook
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/benchkram/bob/bobtask"
+)
+
+func TestDisabledTask(t *testing.T) {
+	generate := bobtask.Task{
+		CmdDirty: "touch generated",
+		When:     &bobtask.Condition{OS: "plan9"},
+	}
+	generate.SetDisabled(!generate.When.Met(os.Environ()))
+
+	p := newTestPlaybook(t, bobtask.Map{
+		"build":    bobtask.Task{CmdDirty: "touch built", DependsOn: []string{"generate"}},
+		"generate": generate,
+	}, []string{"build"})
+
+	err := p.Build(context.Background())
+	assert.Nil(t, err)
+
+	assert.Equal(t, StateDisabled, p.Tasks["generate"].State())
+	assert.Equal(t, StateCompleted, p.Tasks["build"].State())
+
+	dir := p.Tasks["build"].Dir()
+	_, err = os.Stat(filepath.Join(dir, "generated"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	_, err = os.Stat(filepath.Join(dir, "built"))
+	assert.Nil(t, err)
+}
@@ -0,0 +1,112 @@
+package playbook
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventTaskQueued     EventType = "task-queued"
+	EventTaskStarted    EventType = "task-started"
+	EventTaskCached     EventType = "task-cached"
+	EventTaskCompleted  EventType = "task-completed"
+	EventTaskFailed     EventType = "task-failed"
+	EventTaskCanceled   EventType = "task-canceled"
+	EventTaskSkipped    EventType = "task-skipped"
+	EventTaskDisabled   EventType = "task-disabled"
+	EventArtifactPulled EventType = "artifact-pulled"
+	EventArtifactPushed EventType = "artifact-pushed"
+)
+
+// Event is emitted on every state transition of a task
+// and when an artifact is synced with the remote store.
+type Event struct {
+	Type EventType `json:"type"`
+	Time time.Time `json:"time"`
+	Task string    `json:"task"`
+
+	// InputHash and Cause are only set after
+	// the task's rebuild check.
+	InputHash string       `json:"inputHash,omitempty"`
+	Cause     RebuildCause `json:"cause,omitempty"`
+
+	// Start and End are only set for tasks which are done.
+	Start *time.Time `json:"start,omitempty"`
+	End   *time.Time `json:"end,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// EventHandler is called for each event emitted by a playbook.
+// Calls are never concurrent.
+type EventHandler func(Event)
+
+// JSONLEventHandler writes each event as a single line of json to w.
+func JSONLEventHandler(w io.Writer) EventHandler {
+	encoder := json.NewEncoder(w)
+	return func(e Event) {
+		_ = encoder.Encode(e)
+	}
+}
+
+// stateEvents maps task states to the emitted event type.
+var stateEvents = map[State]EventType{
+	StateQueued:            EventTaskQueued,
+	StateRunning:           EventTaskStarted,
+	StateNoRebuildRequired: EventTaskCached,
+	StateCompleted:         EventTaskCompleted,
+	StateFailed:            EventTaskFailed,
+	StateCanceled:          EventTaskCanceled,
+	StateSkipped:           EventTaskSkipped,
+	StateDisabled:          EventTaskDisabled,
+}
+
+// emitStateEvent emits the event belonging to a state transition of a task.
+func (p *Playbook) emitStateEvent(task *Status, state State, taskError error) {
+	if p.eventHandler == nil {
+		return
+	}
+
+	eventType, ok := stateEvents[state]
+	if !ok {
+		return
+	}
+
+	e := p.newEvent(eventType, task)
+	switch state {
+	case StateCompleted, StateCanceled, StateNoRebuildRequired, StateFailed, StateSkipped, StateDisabled:
+		start, end := task.Start(), task.End()
+		e.Start = &start
+		e.End = &end
+	}
+	if taskError != nil {
+		e.Error = taskError.Error()
+	}
+
+	p.emit(e)
+}
+
+func (p *Playbook) newEvent(eventType EventType, task *Status) Event {
+	inputHash, cause := task.RebuildInfo()
+	return Event{
+		Type:      eventType,
+		Time:      time.Now(),
+		Task:      task.Name(),
+		InputHash: inputHash.String(),
+		Cause:     cause,
+	}
+}
+
+// emit passes an event to the event handler.
+func (p *Playbook) emit(e Event) {
+	if p.eventHandler == nil {
+		return
+	}
+
+	p.eventMutex.Lock()
+	defer p.eventMutex.Unlock()
+	p.eventHandler(e)
+}
@@ -0,0 +1,87 @@
+package playbook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/benchkram/bob/bobtask"
+)
+
+func TestJSONLEvents(t *testing.T) {
+	var buf bytes.Buffer
+	p := newTestPlaybook(t, bobtask.Map{
+		"build":    bobtask.Task{CmdDirty: "echo build", DependsOn: []string{"generate"}},
+		"generate": bobtask.Task{CmdDirty: "echo generate"},
+	}, []string{"build"}, WithEventHandler(JSONLEventHandler(&buf)))
+
+	err := p.Build(context.Background())
+	assert.Nil(t, err)
+
+	events := []Event{}
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var e Event
+		assert.Nil(t, decoder.Decode(&e))
+		events = append(events, e)
+	}
+
+	sequence := []string{}
+	for _, e := range events {
+		sequence = append(sequence, e.Task+" "+string(e.Type))
+	}
+	assert.Equal(t, []string{
+		"generate task-queued",
+		"generate task-started",
+		"generate task-completed",
+		"build task-queued",
+		"build task-started",
+		"build task-completed",
+	}, sequence)
+
+	causes := map[string]RebuildCause{
+		"generate": InputNotFoundInBuildInfo,
+		"build":    DependencyChanged,
+	}
+	for _, e := range events {
+		if e.Type != EventTaskCompleted {
+			assert.Nil(t, e.Start, e.Task)
+			continue
+		}
+		assert.NotEmpty(t, e.InputHash, e.Task)
+		assert.Equal(t, causes[e.Task], e.Cause, e.Task)
+		if assert.NotNil(t, e.Start, e.Task) && assert.NotNil(t, e.End, e.Task) {
+			assert.False(t, e.End.Before(*e.Start), e.Task)
+		}
+	}
+}
+
+func TestKeepGoingEvents(t *testing.T) {
+	var buf bytes.Buffer
+	p := newTestPlaybook(t, bobtask.Map{
+		"all":   bobtask.Task{DependsOn: []string{"build", "lint"}},
+		"build": bobtask.Task{CmdDirty: "exit 1"},
+		"lint":  bobtask.Task{CmdDirty: "echo lint"},
+	}, []string{"all"}, WithKeepGoing(true), WithEventHandler(JSONLEventHandler(&buf)))
+
+	err := p.Build(context.Background())
+	assert.NotNil(t, err)
+
+	assert.Equal(t, StateFailed, p.Tasks["build"].State())
+	assert.NotNil(t, p.Tasks["build"].Error)
+	assert.Equal(t, StateCompleted, p.Tasks["lint"].State())
+	assert.Equal(t, StateSkipped, p.Tasks["all"].State())
+
+	counts := map[string]int{}
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var e Event
+		assert.Nil(t, decoder.Decode(&e))
+		counts[e.Task+" "+string(e.Type)]++
+	}
+	assert.Equal(t, 1, counts["build task-failed"])
+	assert.Equal(t, 1, counts["all task-skipped"])
+}
@@ -0,0 +1,144 @@
+package playbook
+
+import (
+	"context"
+	"sort"
+
+	"github.com/benchkram/bob/bobtask/hash"
+	"github.com/benchkram/errz"
+)
+
+// Decision is what a build would do with a task.
+type Decision string
+
+const (
+	DecisionCached          Decision = "cached"
+	DecisionExtractArtifact Decision = "extract-artifact"
+	DecisionPullArtifact    Decision = "pull-artifact"
+	DecisionRebuild         Decision = "rebuild"
+	DecisionDisabled        Decision = "disabled"
+)
+
+// Explanation describes the rebuild decision for a single task.
+type Explanation struct {
+	TaskName  string
+	InputHash hash.In
+
+	Decision Decision
+	// Cause is empty when no rebuild is required.
+	Cause RebuildCause
+
+	// InvalidFiles are the target files which are missing or changed.
+	InvalidFiles []string
+
+	// InLocalStore is true when the artifact exists in the local store.
+	InLocalStore bool
+	// InRemoteStore is true when the artifact exists in the remote store.
+	// The remote store is only asked if the artifact isn't found locally.
+	InRemoteStore bool
+}
+
+// Explain walks the playbook and computes the rebuild decision of each task
+// without executing any of them. Nothing is written to the filesystem
+// or to the artifact and buildinfo stores.
+//
+// The playbook is unusable for a build afterwards.
+func (p *Playbook) Explain(ctx context.Context) (_ []*Explanation, err error) {
+	defer errz.Recover(&err)
+
+	p.prepareOptimizedAccess()
+
+	explanations := []*Explanation{}
+	seen := map[string]bool{}
+
+	err = p.walkBottomFirst(func(taskID int, task *Status, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if seen[task.Name()] {
+			return nil
+		}
+		seen[task.Name()] = true
+
+		e, err := p.explain(ctx, task)
+		if err != nil {
+			return err
+		}
+		explanations = append(explanations, e)
+
+		// Simulate the outcome of the task to let dependent tasks
+		// see the same state as in a real build.
+		switch e.Decision {
+		case DecisionRebuild:
+			return p.setTaskState(taskID, StateCompleted, nil)
+		case DecisionDisabled:
+			return p.setTaskState(taskID, StateDisabled, nil)
+		}
+		return p.setTaskState(taskID, StateNoRebuildRequired, nil)
+	})
+	errz.Fatal(err)
+
+	return explanations, nil
+}
+
+// explain computes the rebuild decision of a single task,
+// mirroring the decisions made in build().
+func (p *Playbook) explain(ctx context.Context, task *Status) (_ *Explanation, err error) {
+	defer errz.Recover(&err)
+
+	if task.Disabled() {
+		return &Explanation{
+			TaskName:     task.Name(),
+			Decision:     DecisionDisabled,
+			InvalidFiles: []string{},
+		}, nil
+	}
+
+	hashIn, err := task.HashIn()
+	errz.Fatal(err)
+
+	rebuild, err := p.TaskNeedsRebuild(task.TaskID)
+	errz.Fatal(err)
+
+	e := &Explanation{
+		TaskName:     task.Name(),
+		InputHash:    hashIn,
+		Decision:     DecisionCached,
+		Cause:        rebuild.Cause,
+		InvalidFiles: []string{},
+	}
+
+	for f := range rebuild.VerifyResult.InvalidFiles {
+		e.InvalidFiles = append(e.InvalidFiles, f)
+	}
+	sort.Strings(e.InvalidFiles)
+
+	// Artifacts only exist for tasks with targets.
+	if p.enableCaching && task.TargetExists() {
+		e.InLocalStore = task.ArtifactExists(hashIn)
+		if !e.InLocalStore && p.remoteStore != nil {
+			e.InRemoteStore = p.remoteStore.ArtifactExists(ctx, hashIn.String())
+		}
+	}
+
+	if !rebuild.IsRequired {
+		return e, nil
+	}
+
+	e.Decision = DecisionRebuild
+	switch rebuild.Cause {
+	case InputNotFoundInBuildInfo:
+		if e.InLocalStore {
+			e.Decision = DecisionExtractArtifact
+		} else if e.InRemoteStore && p.enablePull && p.localStore != nil {
+			e.Decision = DecisionPullArtifact
+		}
+	case TargetInvalid:
+		if e.InLocalStore {
+			e.Decision = DecisionExtractArtifact
+		}
+	}
+
+	return e, nil
+}
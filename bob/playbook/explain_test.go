@@ -0,0 +1,41 @@
+package playbook
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/benchkram/bob/bobtask"
+)
+
+func TestExplainCauses(t *testing.T) {
+	p := newTestPlaybook(t, bobtask.Map{
+		"all":      bobtask.Task{DependsOn: []string{"build", "lint"}},
+		"build":    bobtask.Task{CmdDirty: "echo build", DependsOn: []string{"generate"}},
+		"generate": bobtask.Task{CmdDirty: "echo generate", RebuildDirty: string(bobtask.RebuildAlways)},
+		"lint":     bobtask.Task{CmdDirty: "touch lint"},
+	}, []string{"all"})
+
+	explanations, err := p.Explain(context.Background())
+	assert.Nil(t, err)
+
+	causes := map[string]RebuildCause{}
+	for _, e := range explanations {
+		assert.Equal(t, DecisionRebuild, e.Decision, e.TaskName)
+		assert.NotEmpty(t, e.InputHash, e.TaskName)
+		causes[e.TaskName] = e.Cause
+	}
+	assert.Equal(t, map[string]RebuildCause{
+		"all":      DependencyChanged,
+		"build":    DependencyChanged,
+		"generate": TaskForcedRebuild,
+		"lint":     InputNotFoundInBuildInfo,
+	}, causes)
+
+	// nothing is executed
+	_, err = os.Stat(filepath.Join(p.Tasks["lint"].Dir(), "lint"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -4,21 +4,47 @@ import (
 	"fmt"
 )
 
+// prepareOptimizedAccess translates dependent task names
+// to id's and stores them in the task.
+func (p *Playbook) prepareOptimizedAccess() {
+	p.oncePrepareOptimizedAccess.Do(func() {
+		visited := make(map[string]bool)
+		for _, root := range p.roots {
+			p.rootIDs = append(p.rootIDs, p.Tasks[root].TaskID)
+
+			_ = p.Tasks.walk(root, func(taskname string, task *Status, _ error) error {
+				if visited[taskname] {
+					return nil
+				}
+				visited[taskname] = true
+
+				for _, dependentTaskName := range task.DependsOn {
+					t := p.Tasks[dependentTaskName]
+					task.DependsOnIDs = append(task.DependsOnIDs, t.TaskID)
+				}
+				return nil
+			})
+		}
+	})
+}
+
+// walkBottomFirst walks the task tree of each root. Tasks deeper in the tree are walked first.
+func (p *Playbook) walkBottomFirst(fn func(taskID int, _ *Status, _ error) error) error {
+	for _, rootID := range p.rootIDs {
+		err := p.TasksOptimized.walkBottomFirst(rootID, fn)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (p *Playbook) Next() (_ *Status, err error) {
 	if p.done {
 		return nil, ErrDone
 	}
 
-	// translate dependen tasks name to id's and store them in the task.
-	p.oncePrepareOptimizedAccess.Do(func() {
-		_ = p.Tasks.walk(p.root, func(taskname string, task *Status, _ error) error {
-			for _, dependentTaskName := range task.DependsOn {
-				t := p.Tasks[dependentTaskName]
-				task.DependsOnIDs = append(task.DependsOnIDs, t.TaskID)
-			}
-			return nil
-		})
-	})
+	p.prepareOptimizedAccess()
 
 	// Walk the task chain and determine the next build task. Send it to the task channel.
 	// Returns `taskQueued` when a task has been send to the taskChannel.
@@ -38,33 +64,52 @@ func (p *Playbook) Next() (_ *Status, err error) {
 	// from Next().
 	go func(output chan result) {
 		didAllTaskComplete := true
-		_ = p.TasksOptimized.walkBottomFirst(p.rootID, func(taskID int, task *Status, err error) error {
+		_ = p.walkBottomFirst(func(taskID int, task *Status, err error) error {
 			if err != nil {
 				return err
 			}
 
 			switch task.State() {
 			case StatePending:
+				// In keep-going mode a task with a failed (or skipped)
+				// dependency can never run, so it's skipped as well.
+				if p.keepGoing && p.didDependencyFail(task) {
+					_ = p.TaskSkipped(task.TaskID)
+					return nil
+				}
+
 				didAllTaskComplete = false
 				// Check if all dependent tasks are completed
 				for _, dependentTaskID := range task.Task.DependsOnIDs {
 					t := p.TasksOptimized[dependentTaskID]
 
 					state := t.State()
-					if state != StateCompleted && state != StateNoRebuildRequired {
+					if state != StateCompleted && state != StateNoRebuildRequired && state != StateDisabled {
 						// A dependent task is not completed.
 						// So this task is not yet ready to run.
 						return nil
 					}
 				}
+
+				// Wait for other tasks to release occupied resources.
+				if !p.resources.tryAcquire(task.TaskID, task.Resources()) {
+					return nil
+				}
 			case StateFailed:
+				if p.keepGoing {
+					return nil
+				}
 				output <- result{t: task, state: "failed"}
 				return taskFailed
+			case StateSkipped:
+				return nil
 			case StateCanceled:
 				output <- result{t: task, state: "canceled"}
 				return nil
 			case StateNoRebuildRequired:
 				return nil
+			case StateDisabled:
+				return nil
 			case StateCompleted:
 				return nil
 			case StateRunning:
@@ -105,3 +150,14 @@ func (p *Playbook) Next() (_ *Status, err error) {
 	return nil, nil
 
 }
+
+// didDependencyFail checks if a direct dependency of a task failed or was skipped.
+func (p *Playbook) didDependencyFail(task *Status) bool {
+	for _, dependentTaskID := range task.Task.DependsOnIDs {
+		state := p.TasksOptimized[dependentTaskID].State()
+		if state == StateFailed || state == StateSkipped {
+			return true
+		}
+	}
+	return false
+}
@@ -24,6 +24,46 @@ func WithPullEnabled(enable bool) Option {
 	}
 }
 
+// WithKeepGoing continues building independent tasks
+// after a task failed.
+func WithKeepGoing(keepGoing bool) Option {
+	return func(p *Playbook) {
+		p.keepGoing = keepGoing
+	}
+}
+
+// WithReplayOutput prints the output captured on the last
+// run of tasks which don't need a rebuild.
+func WithReplayOutput(replay bool) Option {
+	return func(p *Playbook) {
+		p.replayOutput = replay
+	}
+}
+
+// WithEventHandler sets a callback which is called
+// on every task state transition.
+func WithEventHandler(handler EventHandler) Option {
+	return func(p *Playbook) {
+		p.eventHandler = handler
+	}
+}
+
+// WithTraceFile writes a chrome trace event file
+// of the build to path.
+func WithTraceFile(path string) Option {
+	return func(p *Playbook) {
+		p.traceFile = path
+	}
+}
+
+// WithResources sets the capacity of resources
+// occupied by tasks.
+func WithResources(capacity map[string]int) Option {
+	return func(p *Playbook) {
+		p.resources = newResourcePool(capacity)
+	}
+}
+
 func WithPredictedNumOfTasks(tasks int) Option {
 	return func(p *Playbook) {
 		p.predictedNumOfTasks = tasks
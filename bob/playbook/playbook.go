@@ -31,10 +31,10 @@ type Playbook struct {
 	// errorChannel to transport errors to the caller
 	errorChannel chan error
 
-	// root task
-	root string
-	// rootID for optimized access
-	rootID int
+	// roots are the tasks the playbook is build for
+	roots []string
+	// rootIDs for optimized access
+	rootIDs []int
 
 	Tasks StatusMap
 	// TasksOptimized uses a array instead of an map
@@ -72,20 +72,41 @@ type Playbook struct {
 	// enablePull allows pulling artifacts from remote store
 	enablePull bool
 
+	// keepGoing continues to process independent tasks
+	// after a task has failed. Dependent tasks of a failed
+	// task are skipped.
+	keepGoing bool
+
+	// replayOutput prints the captured output of tasks
+	// which are not rebuilt.
+	replayOutput bool
+
+	// eventHandler is called on every task state transition.
+	eventHandler EventHandler
+	// eventMutex assures the eventHandler is never called concurrently.
+	eventMutex sync.Mutex
+
+	// resources limits the concurrency of tasks
+	// occupying the same resources.
+	resources *resourcePool
+
+	// traceFile is the path a chrome trace of the build is written to.
+	traceFile string
+	tracer    *tracer
+
 	// oncePrepareOptimizedAccess is used to initalize the optimized
 	// slice to access tasks.
 	oncePrepareOptimizedAccess sync.Once
 }
 
-func New(root string, rootID int, opts ...Option) *Playbook {
+func New(roots []string, opts ...Option) *Playbook {
 	p := &Playbook{
 		errorChannel:   make(chan error),
 		Tasks:          make(StatusMap),
 		TasksOptimized: make(StatusSlice, 0),
 		doneChannel:    make(chan struct{}),
 		enableCaching:  true,
-		root:           root,
-		rootID:         rootID,
+		roots:          roots,
 
 		maxParallel: runtime.NumCPU(),
 
@@ -99,6 +120,10 @@ func New(root string, rootID int, opts ...Option) *Playbook {
 		opt(p)
 	}
 
+	if p.resources == nil {
+		p.resources = newResourcePool(nil)
+	}
+
 	// Try to make the task channel the same size as the number of tasks.
 	// (Matthias) There was a reason why this was neccessary, probably it's related
 	// to beeing able to shutdown the playbook correctly? Unsure!
@@ -134,6 +159,11 @@ func (p *Playbook) ErrorChannel() <-chan error {
 	return p.errorChannel
 }
 
+// Start returns the point in time the playbook started.
+func (p *Playbook) Start() time.Time {
+	return p.start
+}
+
 func (p *Playbook) ExecutionTime() time.Duration {
 	return time.Since(p.start)
 }
@@ -164,7 +194,9 @@ func (p *Playbook) TaskCompleted(taskID int) (err error) {
 	if p.enableCaching {
 		hashIn, err := task.HashIn()
 		errz.Fatal(err)
+		endSpan := p.traceSpan(task, SpanArtifactCreate)
 		err = p.artifactCreate(task.Name(), hashIn)
+		endSpan()
 		errz.Fatal(err)
 	}
 
@@ -195,6 +227,26 @@ func (p *Playbook) TaskFailed(taskID int, taskErr error) (err error) {
 	return nil
 }
 
+// TaskSkipped sets a task to skipped
+func (p *Playbook) TaskSkipped(taskID int) (err error) {
+	defer errz.Recover(&err)
+
+	err = p.setTaskState(taskID, StateSkipped, nil)
+	errz.Fatal(err)
+
+	return nil
+}
+
+// TaskDisabled sets a task to disabled
+func (p *Playbook) TaskDisabled(taskID int) (err error) {
+	defer errz.Recover(&err)
+
+	err = p.setTaskState(taskID, StateDisabled, nil)
+	errz.Fatal(err)
+
+	return nil
+}
+
 // TaskCanceled sets a task to canceled
 func (p *Playbook) TaskCanceled(taskID int) (err error) {
 
@@ -246,10 +298,13 @@ func (p *Playbook) setTaskState(taskID int, state State, taskError error) error
 
 	task.SetState(state, taskError)
 	switch state {
-	case StateCompleted, StateCanceled, StateNoRebuildRequired, StateFailed:
+	case StateCompleted, StateCanceled, StateNoRebuildRequired, StateFailed, StateSkipped, StateDisabled:
 		task.SetEnd(time.Now())
+		p.resources.release(taskID, task.Resources())
 	}
 
+	p.emitStateEvent(task, state, taskError)
+
 	return nil
 }
 
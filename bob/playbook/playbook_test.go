@@ -0,0 +1,59 @@
+package playbook
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/benchkram/bob/bobtask"
+	"github.com/benchkram/bob/pkg/buildinfostore"
+	"github.com/benchkram/bob/pkg/envutil"
+	"github.com/benchkram/bob/pkg/store/filestore"
+)
+
+// newTestPlaybook creates a playbook building roots from tasks. The tasks
+// run in a temporary directory, the buildinfo and artifact stores are
+// temporary as well. Tasks are run one after the other unless overridden
+// by opts.
+func newTestPlaybook(t *testing.T, tasks bobtask.Map, roots []string, opts ...Option) *Playbook {
+	dir := t.TempDir()
+	storeDir := t.TempDir()
+	buildinfoStore := buildinfostore.New(storeDir)
+	localStore := filestore.New(storeDir)
+
+	for name, task := range tasks {
+		task.SetName(name)
+		task.SetDir(dir)
+		tasks[name] = task
+	}
+	assert.Nil(t, tasks.Sanitize())
+
+	for name, task := range tasks {
+		task.WithEnvStore(envutil.Store{"": []string{"PATH=" + os.Getenv("PATH")}})
+		task.WithBuildinfoStore(buildinfoStore)
+		task.WithLocalstore(localStore)
+		tasks[name] = task
+	}
+
+	p := New(roots, append([]Option{WithMaxParallel(1)}, opts...)...)
+	for _, root := range roots {
+		err := tasks.Walk(root, "", func(name string, task bobtask.Task, err error) error {
+			if err != nil {
+				return err
+			}
+			if _, ok := p.Tasks[name]; ok {
+				return nil
+			}
+
+			task.TaskID = len(p.TasksOptimized)
+			status := NewStatus(&task)
+			p.Tasks[name] = status
+			p.TasksOptimized = append(p.TasksOptimized, status)
+			return nil
+		})
+		assert.Nil(t, err)
+	}
+
+	return p
+}
@@ -123,8 +123,8 @@ func (p *Playbook) didChildTaskChange(taskName string) bool {
 			return nil
 		}
 
-		// Check if child task changed
-		if t.State() != StateNoRebuildRequired {
+		// Check if child task changed, disabled tasks never change.
+		if t.State() != StateNoRebuildRequired && t.State() != StateDisabled {
 			return Done
 		}
 
@@ -0,0 +1,74 @@
+package playbook
+
+import "sync"
+
+// resourcePool tracks the resources occupied by running tasks.
+type resourcePool struct {
+	mu sync.Mutex
+
+	// capacity of each resource. Resources without
+	// a declared capacity are exclusive.
+	capacity map[string]int
+	// used amount of each resource.
+	used map[string]int
+	// holders are the ids of tasks currently occupying resources.
+	holders map[int]bool
+}
+
+func newResourcePool(capacity map[string]int) *resourcePool {
+	if capacity == nil {
+		capacity = make(map[string]int)
+	}
+	return &resourcePool{
+		capacity: capacity,
+		used:     make(map[string]int),
+		holders:  make(map[int]bool),
+	}
+}
+
+func (rp *resourcePool) capacityOf(name string) int {
+	if c, ok := rp.capacity[name]; ok {
+		return c
+	}
+	return 1
+}
+
+// tryAcquire occupies the requested resources for a task
+// if all of them are available. Returns false otherwise.
+func (rp *resourcePool) tryAcquire(taskID int, requested map[string]int) bool {
+	if len(requested) == 0 {
+		return true
+	}
+
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	for name, amount := range requested {
+		if rp.used[name]+amount > rp.capacityOf(name) {
+			return false
+		}
+	}
+
+	for name, amount := range requested {
+		rp.used[name] += amount
+	}
+	rp.holders[taskID] = true
+
+	return true
+}
+
+// release the resources occupied by a task.
+// It's a noop for tasks not holding any resources.
+func (rp *resourcePool) release(taskID int, requested map[string]int) {
+	rp.mu.Lock()
+	defer rp.mu.Unlock()
+
+	if !rp.holders[taskID] {
+		return
+	}
+
+	for name, amount := range requested {
+		rp.used[name] -= amount
+	}
+	delete(rp.holders, taskID)
+}
@@ -0,0 +1,73 @@
+package playbook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/benchkram/bob/bobtask"
+)
+
+func TestResources(t *testing.T) {
+	memory := map[string]interface{}{"memory": 2}
+	p := newTestPlaybook(t, bobtask.Map{
+		"all": bobtask.Task{DependsOn: []string{"a", "b", "c", "migrate", "seed"}},
+		"a":   bobtask.Task{CmdDirty: "sleep 0.3", ResourcesDirty: memory},
+		"b":   bobtask.Task{CmdDirty: "sleep 0.3", ResourcesDirty: memory},
+		"c":   bobtask.Task{CmdDirty: "sleep 0.3", ResourcesDirty: memory},
+		// resources without a capacity are exclusive
+		"migrate": bobtask.Task{CmdDirty: "sleep 0.3", ResourcesDirty: []interface{}{"db"}},
+		"seed":    bobtask.Task{CmdDirty: "sleep 0.3", ResourcesDirty: []interface{}{"db"}},
+	}, []string{"all"}, WithMaxParallel(5), WithResources(map[string]int{"memory": 4}))
+
+	err := p.Build(context.Background())
+	assert.Nil(t, err)
+
+	assert.LessOrEqual(t, maxConcurrent(p.Tasks, "a", "b", "c"), 2)
+	assert.LessOrEqual(t, maxConcurrent(p.Tasks, "migrate", "seed"), 1)
+}
+
+// maxConcurrent returns the maximum number of the
+// given tasks which have been running at the same time.
+func maxConcurrent(tasks StatusMap, names ...string) int {
+	max := 0
+	for _, name := range names {
+		start := tasks[name].Start()
+
+		running := 0
+		for _, other := range names {
+			task := tasks[other]
+			if !start.Before(task.Start()) && start.Before(task.End()) {
+				running++
+			}
+		}
+		if running > max {
+			max = running
+		}
+	}
+	return max
+}
+
+func TestResourcePool(t *testing.T) {
+	rp := newResourcePool(map[string]int{"memory": 4})
+	memory := map[string]int{"memory": 2}
+	db := map[string]int{"db": 1}
+
+	assert.True(t, rp.tryAcquire(0, memory))
+	assert.True(t, rp.tryAcquire(1, memory))
+	assert.False(t, rp.tryAcquire(2, memory))
+
+	// resources without a capacity are exclusive
+	assert.True(t, rp.tryAcquire(3, db))
+	assert.False(t, rp.tryAcquire(4, db))
+
+	rp.release(0, memory)
+	assert.True(t, rp.tryAcquire(2, memory))
+
+	// releasing a task not holding resources is a noop
+	rp.release(4, db)
+	assert.False(t, rp.tryAcquire(4, db))
+	rp.release(3, db)
+	assert.True(t, rp.tryAcquire(4, db))
+}
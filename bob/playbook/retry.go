@@ -0,0 +1,58 @@
+package playbook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/benchkram/bob/bobtask"
+	"github.com/benchkram/bob/pkg/boblog"
+	"github.com/logrusorgru/aurora"
+)
+
+// runWithRetries runs a task and retries it on failure
+// as often as defined by the task's retry policy.
+func (p *Playbook) runWithRetries(ctx context.Context, task *bobtask.Task) (err error) {
+	status := p.TasksOptimized[task.TaskID]
+	maxAttempts := task.Retries() + 1
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		status.SetAttempts(attempt)
+
+		err = task.Run(ctx, p.namePad, attempt)
+		if err == nil {
+			if attempt > 1 {
+				p.logAttempt(task, fmt.Sprintf("attempt %d/%d succeeded", attempt, maxAttempts))
+			}
+			return nil
+		}
+
+		msg := fmt.Sprintf("attempt %d/%d failed (%s)", attempt, maxAttempts, err)
+
+		// no retry on the last attempt or in case of cancellation.
+		if attempt == maxAttempts || ctx.Err() != nil {
+			if maxAttempts > 1 {
+				p.logAttempt(task, msg)
+			}
+			break
+		}
+
+		msg += ", retrying"
+		if task.RetryDelay() > 0 {
+			msg += fmt.Sprintf(" in %s", task.RetryDelay())
+		}
+		p.logAttempt(task, msg)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(task.RetryDelay()):
+		}
+	}
+
+	return err
+}
+
+func (p *Playbook) logAttempt(task *bobtask.Task, msg string) {
+	boblog.Log.V(1).Info(fmt.Sprintf("%-*s\t%s", p.namePad, task.ColoredName(), aurora.Yellow(msg)))
+}
@@ -0,0 +1,45 @@
+package playbook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/benchkram/bob/bobtask"
+)
+
+func TestRunWithRetriesFlaky(t *testing.T) {
+	// fails on the first two attempts, succeeds on the third.
+	p := newTestPlaybook(t, bobtask.Map{
+		"flaky": bobtask.Task{
+			CmdDirty:     `sh -c 'echo x >> count; test $(wc -l < count) -ge 3'`,
+			RetriesDirty: 2,
+		},
+	}, []string{"flaky"})
+
+	err := p.Build(context.Background())
+	assert.Nil(t, err)
+
+	status := p.Tasks["flaky"]
+	assert.Equal(t, StateCompleted, status.State())
+	assert.Equal(t, 3, status.Attempts())
+	assert.True(t, status.Flaky())
+}
+
+func TestRunWithRetriesExhausted(t *testing.T) {
+	p := newTestPlaybook(t, bobtask.Map{
+		"broken": bobtask.Task{
+			CmdDirty:     `sh -c 'echo x >> count; exit 1'`,
+			RetriesDirty: 2,
+		},
+	}, []string{"broken"})
+
+	err := p.Build(context.Background())
+	assert.NotNil(t, err)
+
+	status := p.Tasks["broken"]
+	assert.Equal(t, StateFailed, status.State())
+	assert.Equal(t, 3, status.Attempts())
+	assert.False(t, status.Flaky())
+}
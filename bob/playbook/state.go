@@ -20,6 +20,10 @@ func (s *State) Summary() string {
 		return aurora.Faint("canceled").String()
 	case StateQueued:
 		return aurora.Faint("queued").String()
+	case StateSkipped:
+		return aurora.Yellow("skipped").String() + " "
+	case StateDisabled:
+		return aurora.Faint("disabled").String()
 	default:
 		return ""
 	}
@@ -39,6 +43,10 @@ func (s *State) Short() string {
 		return "canceled"
 	case StateQueued:
 		return "queued"
+	case StateSkipped:
+		return "skipped"
+	case StateDisabled:
+		return "disabled"
 	default:
 		return ""
 	}
@@ -52,4 +60,6 @@ const (
 	StateRunning           State = "RUNNING"
 	StateCanceled          State = "CANCELED"
 	StateQueued            State = "QUEUED"
+	StateSkipped           State = "SKIPPED"
+	StateDisabled          State = "DISABLED"
 )
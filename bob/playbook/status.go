@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/benchkram/bob/bobtask"
+	"github.com/benchkram/bob/bobtask/hash"
 )
 
 // Status holds the state of a task
@@ -20,6 +21,20 @@ type Status struct {
 	endMu   sync.RWMutex
 	end     time.Time
 
+	// attempts counts the executions of the task
+	// including retries.
+	attemptsMu sync.RWMutex
+	attempts   int
+
+	// inputHash and cause are set after the rebuild check.
+	rebuildInfoMu sync.RWMutex
+	inputHash     hash.In
+	cause         RebuildCause
+
+	// workerID of the worker which processed the task.
+	workerIDMu sync.RWMutex
+	workerID   int
+
 	Error error
 }
 
@@ -79,3 +94,47 @@ func (ts *Status) SetEnd(end time.Time) {
 	defer ts.endMu.Unlock()
 	ts.end = end
 }
+
+func (ts *Status) Attempts() int {
+	ts.attemptsMu.RLock()
+	defer ts.attemptsMu.RUnlock()
+	return ts.attempts
+}
+
+func (ts *Status) SetAttempts(attempts int) {
+	ts.attemptsMu.Lock()
+	defer ts.attemptsMu.Unlock()
+	ts.attempts = attempts
+}
+
+// Flaky is true when a task only succeeded after a retry.
+func (ts *Status) Flaky() bool {
+	return ts.Attempts() > 1 && ts.State() == StateCompleted
+}
+
+// RebuildInfo returns the input hash and the rebuild cause
+// determined by the rebuild check.
+func (ts *Status) RebuildInfo() (hash.In, RebuildCause) {
+	ts.rebuildInfoMu.RLock()
+	defer ts.rebuildInfoMu.RUnlock()
+	return ts.inputHash, ts.cause
+}
+
+func (ts *Status) SetRebuildInfo(inputHash hash.In, cause RebuildCause) {
+	ts.rebuildInfoMu.Lock()
+	defer ts.rebuildInfoMu.Unlock()
+	ts.inputHash = inputHash
+	ts.cause = cause
+}
+
+func (ts *Status) WorkerID() int {
+	ts.workerIDMu.RLock()
+	defer ts.workerIDMu.RUnlock()
+	return ts.workerID
+}
+
+func (ts *Status) SetWorkerID(id int) {
+	ts.workerIDMu.Lock()
+	defer ts.workerIDMu.Unlock()
+	ts.workerID = id
+}
@@ -2,6 +2,8 @@ package playbook
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/benchkram/bob/bobtask/processed"
 	"github.com/benchkram/bob/pkg/boblog"
@@ -21,7 +23,7 @@ func (p *Playbook) summary(processedTasks []*processed.Task) {
 	for _, t := range processedTasks {
 		stat, err := p.TaskStatus(t.Name())
 		if err != nil {
-			fmt.Println(err)
+			boblog.Log.Error(err, "failed to get task status")
 			continue
 		}
 
@@ -29,9 +31,61 @@ func (p *Playbook) summary(processedTasks []*processed.Task) {
 		status := stat.State()
 		execTime = fmt.Sprintf("\t(%s)", format.DisplayDuration(stat.ExecutionTime()))
 
+		flaky := ""
+		if stat.Flaky() {
+			flaky = aurora.Yellow(fmt.Sprintf("\tflaky (passed on attempt %d)", stat.Attempts())).String()
+		}
+
 		taskName := t.Name()
-		boblog.Log.V(1).Info(fmt.Sprintf("  %-*s\t%s%s", p.namePad, taskName, status.Summary(), execTime))
+		boblog.Log.V(1).Info(fmt.Sprintf("  %-*s\t%s%s%s", p.namePad, taskName, status.Summary(), execTime, flaky))
+
+	}
+
+	if p.keepGoing {
+		// Skipped tasks are never passed to a worker
+		// and are therefore not part of processedTasks.
+		for _, taskName := range p.tasksInState(StateSkipped) {
+			status := StateSkipped
+			boblog.Log.V(1).Info(fmt.Sprintf("  %-*s\t%s", p.namePad, taskName, status.Summary()))
+		}
+
+		failed := p.tasksInState(StateFailed)
+		if len(failed) > 0 {
+			boblog.Log.V(1).Info("")
+			boblog.Log.V(1).Info(aurora.Bold(fmt.Sprintf("%d tasks failed", len(failed))).Red().String())
+			for _, taskName := range failed {
+				stat := p.Tasks[taskName]
+				msg := "unknown error"
+				if stat.Error != nil {
+					msg = stat.Error.Error()
+				}
+				boblog.Log.V(1).Info(fmt.Sprintf("  %-*s\t%s", p.namePad, taskName, aurora.Red(msg)))
+			}
+		}
+	}
 
+	path, duration := p.criticalPath()
+	if duration > 0 {
+		names := make([]string, 0, len(path))
+		for _, t := range path {
+			names = append(names, t.Name())
+		}
+		boblog.Log.V(1).Info("")
+		boblog.Log.V(1).Info(fmt.Sprintf("Critical path (%s): %s", format.DisplayDuration(duration), strings.Join(names, " -> ")))
+		boblog.Log.V(1).Info(fmt.Sprintf("Parallelism: %.1fx", p.parallelism()))
 	}
 	boblog.Log.V(1).Info("")
 }
+
+// tasksInState returns the alphabetically sorted names
+// of all tasks in the given state.
+func (p *Playbook) tasksInState(state State) []string {
+	names := []string{}
+	for name, t := range p.Tasks {
+		if t.State() == state {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
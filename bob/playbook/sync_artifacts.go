@@ -23,7 +23,15 @@ func (p *Playbook) pullArtifact(ctx context.Context, a hash.In, task *bobtask.Ta
 
 	description := fmt.Sprintf("%-*s\t  %s", p.namePad, task.ColoredName(), aurora.Faint("pulling artifact "+a.String()))
 	ctx = context.WithValue(ctx, TaskKey("description"), description)
-	return pull(ctx, p.remoteStore, p.localStore, a, p.namePad, task, ignoreLocal)
+	pulled, err := pull(ctx, p.remoteStore, p.localStore, a, p.namePad, task, ignoreLocal)
+	if err != nil {
+		return err
+	}
+
+	if pulled {
+		p.emit(p.newEvent(EventArtifactPulled, p.TasksOptimized[task.TaskID]))
+	}
+	return nil
 }
 
 func (p *Playbook) pushArtifact(ctx context.Context, a hash.In, taskName string) error {
@@ -33,49 +41,62 @@ func (p *Playbook) pushArtifact(ctx context.Context, a hash.In, taskName string)
 
 	description := fmt.Sprintf("  %-*s\t%s", p.namePad, taskName, aurora.Faint("pushing artifact "+a.String()))
 	ctx = context.WithValue(ctx, TaskKey("description"), description)
-	return push(ctx, p.localStore, p.remoteStore, a, taskName, p.namePad)
+	pushed, err := push(ctx, p.localStore, p.remoteStore, a, taskName, p.namePad)
+	if err != nil {
+		return err
+	}
+
+	if pushed {
+		p.emit(p.newEvent(EventArtifactPushed, p.Tasks[taskName]))
+	}
+	return nil
 }
 
 // pull syncs the artifact from the remote store to the local store.
-// if ignoreAlreadyExists is true it will ignore local artifact and perform a fresh download
-func pull(ctx context.Context, remote store.Store, local store.Store, a hash.In, namePad int, task *bobtask.Task, ignoreAlreadyExists bool) error {
+// if ignoreAlreadyExists is true it will ignore local artifact and perform a fresh download.
+// Returns true if the artifact was downloaded.
+func pull(ctx context.Context, remote store.Store, local store.Store, a hash.In, namePad int, task *bobtask.Task, ignoreAlreadyExists bool) (bool, error) {
 	err := store.Sync(ctx, remote, local, a.String(), ignoreAlreadyExists)
 	if errors.Is(err, store.ErrArtifactAlreadyExists) {
 		boblog.Log.V(5).Info(fmt.Sprintf("artifact already exists locally [artifactId: %s]. skipping...", a.String()))
+		return false, nil
 	} else if errors.Is(err, store.ErrArtifactNotFoundinSrc) {
 		boblog.Log.V(5).Info(fmt.Sprintf("failed to pull [artifactId: %s]", a.String()))
+		return false, nil
 	} else if errors.Is(err, context.Canceled) {
-		return usererror.Wrap(err)
+		return false, usererror.Wrap(err)
 	} else if err != nil {
-		fmt.Printf("%-*s\t%s\n",
+		boblog.Log.Info(fmt.Sprintf("%-*s\t%s",
 			namePad,
 			task.ColoredName(),
 			aurora.Red(fmt.Errorf("failed pull [artifactId: %s]: %w", a.String(), err)),
-		)
+		))
+		return false, nil
 	}
 
 	boblog.Log.V(5).Info(fmt.Sprintf("pull succeeded [artifactId: %s]", a.String()))
-	return nil
+	return true, nil
 }
 
 // push syncs the artifact from the local store to the remote store.
-func push(ctx context.Context, local store.Store, remote store.Store, a hash.In, taskName string, namePad int) error {
+// Returns true if the artifact was uploaded.
+func push(ctx context.Context, local store.Store, remote store.Store, a hash.In, taskName string, namePad int) (bool, error) {
 	err := store.Sync(ctx, local, remote, a.String(), false)
 	if errors.Is(err, store.ErrArtifactAlreadyExists) {
 		boblog.Log.V(5).Info(fmt.Sprintf("artifact already exists on the remote [artifactId: %s]. skipping...", a.String()))
-		return nil
+		return false, nil
 	} else if errors.Is(err, context.Canceled) {
-		return nil // cancel err is handled after remote.Done()
+		return false, nil // cancel err is handled after remote.Done()
 	} else if err != nil {
-		return fmt.Errorf("  %-*s\tfailed push [artifactId: %s]: %w", namePad, taskName, a.String(), err)
+		return false, fmt.Errorf("  %-*s\tfailed push [artifactId: %s]: %w", namePad, taskName, a.String(), err)
 	}
 
 	// wait for the remote store to finish uploading this artifact. can be moved outside the for loop, but then
 	// we don't know which artifacts failed to upload.
 	err = remote.Done()
 	if err != nil {
-		return fmt.Errorf("  %-*s\tfailed push [artifactId: %s]: cancelled", namePad, taskName, a.String())
+		return false, fmt.Errorf("  %-*s\tfailed push [artifactId: %s]: cancelled", namePad, taskName, a.String())
 	}
 	boblog.Log.V(5).Info(fmt.Sprintf("push succeeded [artifactId: %s]", a.String()))
-	return nil
+	return true, nil
 }
@@ -0,0 +1,127 @@
+package playbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Span names recorded in a trace.
+const (
+	SpanTask            = "task"
+	SpanHash            = "hash"
+	SpanRebuildCheck    = "rebuild-check"
+	SpanArtifactPull    = "artifact-pull"
+	SpanArtifactExtract = "artifact-extract"
+	SpanRun             = "run"
+	SpanArtifactCreate  = "artifact-create"
+)
+
+// traceEvent is a single event of the chrome trace event format.
+// See https://docs.google.com/document/d/1CvAClvFfyA5R-PhYUmn5OOQtYMH4h6I0nSsKchNAySU
+type traceEvent struct {
+	Name string            `json:"name"`
+	Cat  string            `json:"cat,omitempty"`
+	Ph   string            `json:"ph"`
+	Ts   int64             `json:"ts"`
+	Dur  int64             `json:"dur"`
+	Pid  int               `json:"pid"`
+	Tid  int               `json:"tid"`
+	Args map[string]string `json:"args,omitempty"`
+}
+
+type traceFile struct {
+	TraceEvents     []traceEvent `json:"traceEvents"`
+	DisplayTimeUnit string       `json:"displayTimeUnit"`
+}
+
+// tracer collects spans of a playbook run. Each worker
+// is represented as its own track (thread) in the trace.
+type tracer struct {
+	start time.Time
+
+	mu     sync.Mutex
+	events []traceEvent
+}
+
+func newTracer() *tracer {
+	return &tracer{
+		start:  time.Now(),
+		events: []traceEvent{},
+	}
+}
+
+// span records a complete event on the track of the given worker.
+// The span enclosing all others of a task is named after the task.
+func (t *tracer) span(workerID int, kind, taskName string, start, end time.Time) {
+	name := kind
+	if kind == SpanTask {
+		name = taskName
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.events = append(t.events, traceEvent{
+		Name: name,
+		Cat:  kind,
+		Ph:   "X",
+		Ts:   start.Sub(t.start).Microseconds(),
+		Dur:  end.Sub(start).Microseconds(),
+		Pid:  1,
+		Tid:  workerID,
+		Args: map[string]string{"task": taskName},
+	})
+}
+
+// writeFile writes the collected spans to path. Metadata
+// events naming the worker tracks are added to the trace.
+func (t *tracer) writeFile(path string, workers int) error {
+	t.mu.Lock()
+	events := make([]traceEvent, 0, len(t.events)+workers)
+	for i := 0; i < workers; i++ {
+		events = append(events, traceEvent{
+			Name: "thread_name",
+			Ph:   "M",
+			Pid:  1,
+			Tid:  i,
+			Args: map[string]string{"name": fmt.Sprintf("worker %d", i)},
+		})
+	}
+	events = append(events, t.events...)
+	t.mu.Unlock()
+
+	// Enclosing spans must precede the spans they contain.
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].Ts == events[j].Ts {
+			return events[i].Dur > events[j].Dur
+		}
+		return events[i].Ts < events[j].Ts
+	})
+
+	b, err := json.Marshal(traceFile{
+		TraceEvents:     events,
+		DisplayTimeUnit: "ms",
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, b, 0664)
+}
+
+// traceSpan starts a span for a task and returns a function
+// to end it. It's a noop when tracing is disabled.
+func (p *Playbook) traceSpan(task *Status, kind string) func() {
+	if p.tracer == nil {
+		return func() {}
+	}
+
+	start := time.Now()
+	return func() {
+		p.tracer.span(task.WorkerID(), kind, task.Name(), start, time.Now())
+	}
+}
@@ -0,0 +1,73 @@
+package playbook
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/benchkram/bob/bobtask"
+)
+
+func TestTraceFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.json")
+	p := newTestPlaybook(t, bobtask.Map{
+		"all": bobtask.Task{DependsOn: []string{"a", "b"}},
+		"a":   bobtask.Task{CmdDirty: "echo a"},
+		"b":   bobtask.Task{CmdDirty: "echo b"},
+	}, []string{"all"}, WithMaxParallel(2), WithTraceFile(path))
+
+	err := p.Build(context.Background())
+	assert.Nil(t, err)
+
+	b, err := os.ReadFile(path)
+	assert.Nil(t, err)
+
+	var trace traceFile
+	assert.Nil(t, json.Unmarshal(b, &trace))
+	assert.Equal(t, "ms", trace.DisplayTimeUnit)
+
+	workers := map[int]string{}
+	taskSpans := map[string]traceEvent{}
+	spans := map[string][]traceEvent{}
+	for _, e := range trace.TraceEvents {
+		assert.Equal(t, 1, e.Pid)
+		switch e.Ph {
+		case "M":
+			assert.Equal(t, "thread_name", e.Name)
+			workers[e.Tid] = e.Args["name"]
+		case "X":
+			if e.Cat == SpanTask {
+				assert.Equal(t, e.Args["task"], e.Name)
+				taskSpans[e.Name] = e
+			} else {
+				spans[e.Args["task"]] = append(spans[e.Args["task"]], e)
+			}
+		default:
+			t.Errorf("unexpected phase %q", e.Ph)
+		}
+	}
+	assert.Equal(t, map[int]string{0: "worker 0", 1: "worker 1"}, workers)
+
+	assert.Len(t, taskSpans, 3)
+	for name, task := range taskSpans {
+		assert.Contains(t, workers, task.Tid, name)
+
+		kinds := []string{}
+		for _, span := range spans[name] {
+			kinds = append(kinds, span.Name)
+
+			// spans of a task are on the same track and within the task span
+			assert.Equal(t, task.Tid, span.Tid, name)
+			assert.GreaterOrEqual(t, span.Ts, task.Ts, name)
+			assert.LessOrEqual(t, span.Ts+span.Dur, task.Ts+task.Dur, name)
+		}
+		assert.Subset(t, kinds, []string{SpanHash, SpanRebuildCheck}, name)
+		if name != "all" {
+			assert.Contains(t, kinds, SpanRun, name)
+		}
+	}
+}
@@ -94,6 +94,7 @@ func (p *Playbook) startWorkers(ctx context.Context, workers int) *workerManager
 
 			for t := range queue {
 				t.SetStart(time.Now())
+				t.SetWorkerID(workerID)
 				_ = p.setTaskState(t.Task.TaskID, StateRunning, nil)
 
 				// check if a shutdown is required.
@@ -105,8 +106,12 @@ func (p *Playbook) startWorkers(ctx context.Context, workers int) *workerManager
 				if err != nil {
 					wm.addError(fmt.Errorf("(worker) [task: %s], %w", t.Name(), err))
 
-					// stopp workers asap.
-					wm.stopWorkers()
+					// With keepGoing the task is already flagged as failed by build(),
+					// the playbook skips its dependents.
+					if !p.keepGoing {
+						// stopp workers asap.
+						wm.stopWorkers()
+					}
 				}
 				wm.addProcessedTask(processedTask)
 
@@ -0,0 +1,183 @@
+package bob
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/benchkram/errz"
+
+	"github.com/benchkram/bob/bob/bobfile"
+	"github.com/benchkram/bob/pkg/boberror"
+	"github.com/benchkram/bob/pkg/usererror"
+)
+
+var ErrInvalidQuery = fmt.Errorf("invalid query")
+
+// Query functions
+const (
+	QueryDeps    = "deps"
+	QueryRdeps   = "rdeps"
+	QueryOwner   = "owner"
+	QueryTargets = "targets"
+	QueryInputs  = "inputs"
+)
+
+var queryPattern = regexp.MustCompile(`^\s*(\w+)\(\s*(.*?)\s*\)\s*$`)
+
+// Query answers a query over the aggregate, one of
+//
+//	deps(task)     tasks the task depends on, directly or indirectly
+//	rdeps(task)    tasks depending on the task, directly or indirectly
+//	owner(path)    tasks having the file as input or target
+//	targets(task)  target paths and images of a build task
+//	inputs(task)   input files of a build task
+//
+// The results are sorted.
+func (b *B) Query(query string) (results []string, err error) {
+	defer errz.Recover(&err)
+
+	m := queryPattern.FindStringSubmatch(query)
+	if m == nil {
+		return nil, usererror.Wrap(fmt.Errorf("%w `%s`, expected e.g. `deps(build)`", ErrInvalidQuery, query))
+	}
+	function, arg := m[1], m[2]
+
+	ag, err := b.Aggregate()
+	errz.Fatal(err)
+
+	switch function {
+	case QueryDeps, QueryRdeps:
+		results, err = queryDeps(ag, arg, function == QueryRdeps)
+	case QueryOwner:
+		results, err = queryOwner(ag, arg)
+	case QueryTargets, QueryInputs:
+		task, ok := ag.BTasks[arg]
+		if !ok {
+			return nil, usererror.Wrap(boberror.ErrTaskDoesNotExistF(arg))
+		}
+		if function == QueryInputs {
+			results = append(results, task.Inputs()...)
+			break
+		}
+
+		target, err := task.Target()
+		errz.Fatal(err)
+		if target != nil {
+			results = append(results, target.FilesystemEntriesRaw()...)
+			results = append(results, target.DockerImages()...)
+		}
+	default:
+		return nil, usererror.Wrap(fmt.Errorf("%w `%s`, unknown function `%s`, must be one of: %s",
+			ErrInvalidQuery, query, function,
+			strings.Join([]string{QueryDeps, QueryRdeps, QueryOwner, QueryTargets, QueryInputs}, ", ")))
+	}
+	errz.Fatal(err)
+
+	if results == nil {
+		results = []string{}
+	}
+	sort.Strings(results)
+
+	return results, nil
+}
+
+// queryDeps returns the tasks taskname depends on,
+// or with reverse set the tasks depending on taskname.
+func queryDeps(ag *bobfile.Bobfile, taskname string, reverse bool) ([]string, error) {
+	edges := map[string][]string{}
+	for name, task := range ag.BTasks {
+		edges[name] = append(edges[name], task.DependsOn...)
+	}
+	for name, run := range ag.RTasks {
+		edges[name] = append(edges[name], run.DependsOn...)
+	}
+
+	if _, ok := edges[taskname]; !ok {
+		return nil, usererror.Wrap(boberror.ErrTaskDoesNotExistF(taskname))
+	}
+
+	if reverse {
+		reversed := map[string][]string{}
+		for name, deps := range edges {
+			for _, dep := range deps {
+				reversed[dep] = append(reversed[dep], name)
+			}
+		}
+		edges = reversed
+	}
+
+	seen := map[string]bool{}
+	var visit func(name string)
+	visit = func(name string) {
+		for _, dep := range edges[name] {
+			if seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			visit(dep)
+		}
+	}
+	visit(taskname)
+
+	results := []string{}
+	for name := range seen {
+		if name != taskname {
+			results = append(results, name)
+		}
+	}
+	return results, nil
+}
+
+// queryOwner returns the build tasks having path as input or as target,
+// a path inside of a target directory belongs to the target.
+func queryOwner(ag *bobfile.Bobfile, path string) ([]string, error) {
+	if filepath.IsAbs(path) {
+		wd, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		path, err = filepath.Rel(wd, path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	path = filepath.Clean(path)
+
+	results := []string{}
+	for name, task := range ag.BTasks {
+		owns := false
+
+		for _, input := range task.Inputs() {
+			if filepath.Clean(input) == path {
+				owns = true
+				break
+			}
+		}
+
+		if !owns {
+			target, err := task.Target()
+			if err != nil {
+				return nil, err
+			}
+			if target != nil {
+				for _, entry := range target.FilesystemEntriesRaw() {
+					entry = filepath.Clean(entry)
+					if entry == path || strings.HasPrefix(path, entry+string(filepath.Separator)) {
+						owns = true
+						break
+					}
+				}
+			}
+		}
+
+		if owns {
+			results = append(results, name)
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,36 @@
+package bob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/benchkram/bob/bob/bobfile"
+	"github.com/benchkram/bob/bobrun"
+	"github.com/benchkram/bob/bobtask"
+	"github.com/benchkram/bob/pkg/boberror"
+)
+
+func TestQueryDeps(t *testing.T) {
+	ag := bobfile.NewBobfile()
+	ag.BTasks["build"] = bobtask.Task{DependsOn: []string{"sub/gen"}}
+	ag.BTasks["sub/gen"] = bobtask.Task{DependsOn: []string{"sub/proto"}}
+	ag.BTasks["sub/proto"] = bobtask.Task{}
+	ag.BTasks["lint"] = bobtask.Task{}
+	ag.RTasks["server"] = &bobrun.Run{DependsOn: []string{"build"}}
+
+	deps, err := queryDeps(ag, "server", false)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"build", "sub/gen", "sub/proto"}, deps)
+
+	rdeps, err := queryDeps(ag, "sub/gen", true)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{"build", "server"}, rdeps)
+
+	rdeps, err = queryDeps(ag, "lint", true)
+	assert.Nil(t, err)
+	assert.Empty(t, rdeps)
+
+	_, err = queryDeps(ag, "nope", false)
+	assert.ErrorIs(t, err, boberror.ErrTaskDoesNotExist)
+}
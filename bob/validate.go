@@ -0,0 +1,30 @@
+package bob
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/benchkram/errz"
+
+	"github.com/benchkram/bob/bob/bobfile"
+	"github.com/benchkram/bob/pkg/usererror"
+)
+
+// Validate checks the bobfile in the current directory and
+// all imported bobfiles, see bobfile.Check.
+func (b *B) Validate() (_ []bobfile.Problem, err error) {
+	defer errz.Recover(&err)
+
+	problems, err := bobfile.Check(".")
+	if errors.Is(err, bobfile.ErrBobfileNotFound) {
+		return nil, usererror.Wrap(ErrCouldNotFindTopLevelBobfile)
+	}
+	errz.Fatal(err)
+
+	return problems, nil
+}
+
+// Schema returns the JSON Schema of a bob.yaml.
+func Schema() ([]byte, error) {
+	return json.MarshalIndent(bobfile.NewSchema(), "", "  ")
+}
@@ -0,0 +1,265 @@
+package bob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/benchkram/errz"
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/benchkram/bob/bob/bobfile"
+	"github.com/benchkram/bob/bob/playbook"
+	"github.com/benchkram/bob/pkg/boblog"
+	"github.com/benchkram/bob/pkg/sliceutil"
+	"github.com/benchkram/bob/pkg/usererror"
+)
+
+// watchDebounce is the time to wait for further changes
+// before a rebuild is triggered.
+const watchDebounce = 300 * time.Millisecond
+
+// Watch builds the tasks and rebuilds them whenever one of their inputs change.
+// Only the input hashes of affected tasks are recomputed on a rebuild.
+// Blocks until the context is canceled.
+func (b *B) Watch(ctx context.Context, taskNames ...string) (err error) {
+	defer errz.Recover(&err)
+
+	ag, err := b.Aggregate()
+	errz.Fatal(err)
+
+	b.PrintVersionCompatibility(ag)
+
+	err = b.nix.BuildNixDependenciesInPipeline(ag, taskNames...)
+	errz.Fatal(err)
+
+	var tasksInPipeline []string
+	for _, taskName := range taskNames {
+		tasks, err := ag.BTasks.CollectTasksInPipeline(taskName)
+		errz.Fatal(err)
+		tasksInPipeline = append(tasksInPipeline, tasks...)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	errz.Fatal(err)
+	defer watcher.Close()
+
+	wd, err := os.Getwd()
+	errz.Fatal(err)
+
+	w := &inputWatcher{
+		ag:      ag,
+		wd:      wd,
+		tasks:   sliceutil.Unique(tasksInPipeline),
+		watcher: watcher,
+		watched: make(map[string]bool),
+	}
+	err = w.index()
+	errz.Fatal(err)
+
+	build := func() {
+		p, err := b.buildPlaybook(ctx, ag, taskNames)
+		if p != nil {
+			w.keepHashes(p.Tasks)
+		}
+		if err != nil && !errors.Is(err, context.Canceled) {
+			if errors.As(err, &usererror.Err) {
+				boblog.Log.UserError(err)
+			} else {
+				boblog.Log.Error(err, "build failed")
+			}
+		}
+		boblog.Log.V(1).Info("Watching for changes...")
+	}
+	build()
+
+	affected := map[string]bool{}
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			tasks, err := w.affectedTasks(event)
+			if err != nil {
+				boblog.Log.Error(err, "failed to process change")
+				continue
+			}
+			if len(tasks) == 0 {
+				continue
+			}
+			for _, t := range tasks {
+				affected[t] = true
+			}
+			debounce.Reset(watchDebounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			boblog.Log.Error(err, "watcher failed")
+		case <-debounce.C:
+			names := make([]string, 0, len(affected))
+			for name := range affected {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			affected = map[string]bool{}
+
+			boblog.Log.V(1).Info(fmt.Sprintf("Inputs of %v changed, rebuilding", names))
+			err = w.invalidate(names)
+			if err != nil {
+				boblog.Log.Error(err, "failed to update inputs")
+				continue
+			}
+			build()
+		}
+	}
+}
+
+// inputWatcher maps filesystem events to the tasks owning the changed inputs.
+type inputWatcher struct {
+	ag *bobfile.Bobfile
+	wd string
+
+	// tasks are the names of the watched tasks.
+	tasks []string
+
+	watcher *fsnotify.Watcher
+	// watched directories
+	watched map[string]bool
+
+	// files maps an input file to the tasks using it.
+	files map[string][]string
+	// dirs maps a directory to the tasks having inputs in it.
+	dirs map[string][]string
+}
+
+// index maps the inputs of all tasks and watches their directories.
+func (w *inputWatcher) index() error {
+	w.files = make(map[string][]string)
+	w.dirs = make(map[string][]string)
+
+	for _, name := range w.tasks {
+		task := w.ag.BTasks[name]
+		for _, input := range task.Inputs() {
+			w.files[input] = append(w.files[input], name)
+
+			dir := filepath.Dir(input)
+			if !sliceutil.Contains(w.dirs[dir], name) {
+				w.dirs[dir] = append(w.dirs[dir], name)
+			}
+		}
+	}
+
+	for dir := range w.dirs {
+		err := w.watch(dir)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *inputWatcher) watch(dir string) error {
+	if w.watched[dir] {
+		return nil
+	}
+	err := w.watcher.Add(filepath.Join(w.wd, dir))
+	if err != nil {
+		return fmt.Errorf("failed to watch %s: %w", dir, err)
+	}
+	w.watched[dir] = true
+	return nil
+}
+
+// affectedTasks returns the names of the tasks affected by a filesystem event.
+// Created files are only considered when they are an input of a task.
+func (w *inputWatcher) affectedTasks(event fsnotify.Event) (_ []string, err error) {
+	if event.Op == fsnotify.Chmod {
+		return nil, nil
+	}
+
+	path, err := filepath.Rel(w.wd, event.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if tasks, ok := w.files[path]; ok {
+		return tasks, nil
+	}
+
+	if event.Op&fsnotify.Create == 0 {
+		return nil, nil
+	}
+
+	// A new file or directory in a watched directory
+	// might be an input of the tasks owning the directory.
+	dir := filepath.Dir(path)
+	candidates := w.dirs[dir]
+
+	if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+		err = w.watch(path)
+		if err != nil {
+			return nil, err
+		}
+		w.dirs[path] = append(w.dirs[path], candidates...)
+	}
+
+	affected := []string{}
+	for _, name := range candidates {
+		task := w.ag.BTasks[name]
+		inputs, err := task.FilteredInputs(w.wd)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Equal(inputs, task.Inputs()) {
+			task.SetInputs(inputs)
+			w.ag.BTasks[name] = task
+			affected = append(affected, name)
+		}
+	}
+
+	return affected, nil
+}
+
+// invalidate updates the inputs of tasks and drops their input hash,
+// forcing it to be recomputed.
+func (w *inputWatcher) invalidate(names []string) error {
+	for _, name := range names {
+		task := w.ag.BTasks[name]
+		inputs, err := task.FilteredInputs(w.wd)
+		if err != nil {
+			return err
+		}
+		task.SetInputs(inputs)
+		task.ResetHashIn()
+		w.ag.BTasks[name] = task
+	}
+
+	return w.index()
+}
+
+// keepHashes stores the input hashes computed by a playbook
+// run on the aggregate to be reused by the next run.
+func (w *inputWatcher) keepHashes(tasks playbook.StatusMap) {
+	for name, status := range tasks {
+		hashIn, _ := status.RebuildInfo()
+		if hashIn == "" {
+			continue
+		}
+		task := w.ag.BTasks[name]
+		task.SetHashIn(hashIn)
+		w.ag.BTasks[name] = task
+	}
+}
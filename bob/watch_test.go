@@ -0,0 +1,106 @@
+package bob
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/benchkram/bob/bob/bobfile"
+	"github.com/benchkram/bob/bob/global"
+	"github.com/benchkram/bob/bob/playbook"
+	"github.com/benchkram/bob/pkg/buildinfostore"
+	"github.com/benchkram/bob/pkg/envutil"
+	"github.com/benchkram/bob/pkg/store/filestore"
+)
+
+func TestWatchInvalidate(t *testing.T) {
+	dir := t.TempDir()
+	storeDir := t.TempDir()
+
+	err := os.Chdir(dir)
+	assert.Nil(t, err)
+
+	files := map[string]string{
+		global.BobFileName: `build:
+  build:
+    input: build.txt
+    cmd: echo build
+    dependsOn: [generate]
+  generate:
+    input: generate.txt
+    cmd: echo generate
+  lint:
+    input: lint.txt
+    cmd: echo lint
+`,
+		"build.txt":    "build",
+		"generate.txt": "generate",
+		"lint.txt":     "lint",
+	}
+	for name, content := range files {
+		assert.Nil(t, os.WriteFile(name, []byte(content), 0664))
+	}
+
+	ag, err := bobfile.BobfileRead(".")
+	assert.Nil(t, err)
+	buildinfoStore := buildinfostore.New(storeDir)
+	localStore := filestore.New(storeDir)
+	for name, task := range ag.BTasks {
+		task.WithEnvStore(envutil.Store{"": []string{}})
+		task.WithBuildinfoStore(buildinfoStore)
+		task.WithLocalstore(localStore)
+		ag.BTasks[name] = task
+	}
+	assert.Nil(t, ag.BTasks.FilterInputs())
+
+	watcher, err := fsnotify.NewWatcher()
+	assert.Nil(t, err)
+	defer watcher.Close()
+
+	w := &inputWatcher{
+		ag:      ag,
+		wd:      dir,
+		tasks:   []string{"build", "generate", "lint"},
+		watcher: watcher,
+		watched: make(map[string]bool),
+	}
+	assert.Nil(t, w.index())
+
+	build := func() playbook.StatusMap {
+		p, err := ag.PlaybookForTasks([]string{"build", "lint"})
+		assert.Nil(t, err)
+		assert.Nil(t, p.Build(context.Background()))
+		w.keepHashes(p.Tasks)
+		return p.Tasks
+	}
+	build()
+
+	assert.Nil(t, os.WriteFile("generate.txt", []byte("changed"), 0664))
+	affected, err := w.affectedTasks(fsnotify.Event{Name: filepath.Join(dir, "generate.txt"), Op: fsnotify.Write})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"generate"}, affected)
+
+	// unrelated files and chmod events affect no task
+	affected, err = w.affectedTasks(fsnotify.Event{Name: filepath.Join(dir, "unrelated.txt"), Op: fsnotify.Write})
+	assert.Nil(t, err)
+	assert.Empty(t, affected)
+	affected, err = w.affectedTasks(fsnotify.Event{Name: filepath.Join(dir, "lint.txt"), Op: fsnotify.Chmod})
+	assert.Nil(t, err)
+	assert.Empty(t, affected)
+
+	assert.Nil(t, w.invalidate([]string{"generate"}))
+
+	states := map[string]playbook.State{}
+	for name, status := range build() {
+		states[name] = status.State()
+	}
+	assert.Equal(t, map[string]playbook.State{
+		"generate": playbook.StateCompleted,
+		"build":    playbook.StateCompleted,
+		"lint":     playbook.StateNoRebuildRequired,
+	}, states)
+}
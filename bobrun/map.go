@@ -9,6 +9,8 @@ import (
 
 	"github.com/benchkram/bob/pkg/multilinecmd"
 	"github.com/benchkram/bob/pkg/nix"
+	"github.com/benchkram/bob/pkg/shell"
+	"github.com/benchkram/bob/pkg/usererror"
 )
 
 type RunMap map[string]*Run
@@ -40,6 +42,11 @@ func (rm RunMap) Sanitize() (err error) {
 	for key, task := range rm {
 		task.init = multilinecmd.Split(task.InitDirty)
 		task.initOnce = multilinecmd.Split(task.InitOnceDirty)
+
+		task.shell, err = shell.Parse(task.ShellDirty)
+		if err != nil {
+			return usererror.Wrap(fmt.Errorf("%w [task:%s]", err, key))
+		}
 		rm[key] = task
 	}
 
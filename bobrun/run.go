@@ -12,6 +12,7 @@ import (
 	"github.com/benchkram/bob/pkg/ctl"
 	"github.com/benchkram/bob/pkg/execctl"
 	"github.com/benchkram/bob/pkg/nix"
+	"github.com/benchkram/bob/pkg/shell"
 )
 
 var ErrInvalidRunType = fmt.Errorf("invalid run type")
@@ -37,6 +38,11 @@ type Run struct {
 	// initOnce see InitOnceDirty
 	initOnce []string
 
+	// ShellDirty is an external shell executing init commands,
+	// e.g. `bash` or `[python3, -c]`. Defaults to the embedded interpreter.
+	ShellDirty interface{} `yaml:"shell"`
+	shell      shell.Shell
+
 	// DependenciesDirty read from the bobfile
 	DependenciesDirty []string `yaml:"dependencies"`
 
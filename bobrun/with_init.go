@@ -18,8 +18,13 @@ import (
 
 	"github.com/benchkram/bob/pkg/boblog"
 	"github.com/benchkram/bob/pkg/ctl"
+	"github.com/benchkram/bob/pkg/procgroup"
 )
 
+// initKillTimeout is the time a canceled init command
+// is given to terminate before it is killed.
+var initKillTimeout = procgroup.DefaultKillTimeout
+
 // WithInit wraps a run-task to provide init functionality executed after
 // the task started.
 type WithInit struct {
@@ -225,9 +230,6 @@ func (rw *WithInit) shexec(ctx context.Context, cmds []string) (err error) {
 	defer errz.Recover(&err)
 
 	for _, run := range cmds {
-		p, err := syntax.NewParser().Parse(strings.NewReader(run), "")
-		errz.Fatal(err)
-
 		env := rw.run.Env()
 		pr, pw, err := os.Pipe()
 		errz.Fatal(err)
@@ -248,6 +250,27 @@ func (rw *WithInit) shexec(ctx context.Context, cmds []string) (err error) {
 			}
 		}()
 
+		if rw.run.shell != nil {
+			cmd, err := rw.run.shell.Command(run, rw.run.dir, env)
+			errz.Fatal(err)
+			cmd.Stdin = os.Stdin
+			cmd.Stdout = pw
+			cmd.Stderr = pw
+
+			err = procgroup.Start(cmd)
+			errz.Fatal(err)
+
+			// the shell and its children are terminated on cancellation
+			var procs sync.WaitGroup
+			err = procgroup.Wait(ctx, cmd, initKillTimeout, &procs)
+			procs.Wait()
+			errz.Fatal(err)
+			continue
+		}
+
+		p, err := syntax.NewParser().Parse(strings.NewReader(run), "")
+		errz.Fatal(err)
+
 		r, err := interp.New(
 			interp.Params("-e"),
 			interp.Dir(rw.run.dir),
@@ -0,0 +1,76 @@
+//go:build unix
+
+package bobrun
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/benchkram/bob/pkg/procgroup"
+	"github.com/benchkram/bob/pkg/shell"
+)
+
+func TestShexecKillsChildProcesses(t *testing.T) {
+	initKillTimeout = 300 * time.Millisecond
+	defer func() { initKillTimeout = procgroup.DefaultKillTimeout }()
+
+	dir := t.TempDir()
+	rw := &WithInit{run: &Run{
+		dir:   dir,
+		shell: shell.Shell{"sh", "-c"},
+		env:   []string{"PATH=" + os.Getenv("PATH")},
+	}}
+
+	// the shell and its child ignore SIGINT and SIGTERM,
+	// they must be killed after the kill timeout
+	cmd := `trap "" INT TERM; sleep 60 < /dev/null & echo $! > child; echo $$ > pid; wait`
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		// wait for the child to start
+		for {
+			if bin, err := os.ReadFile(filepath.Join(dir, "pid")); err == nil && len(bin) > 0 {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		cancel()
+	}()
+
+	done := make(chan error)
+	go func() { done <- rw.shexec(ctx, []string{cmd}) }()
+
+	select {
+	case err := <-done:
+		assert.NotNil(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("canceled init did not terminate")
+	}
+
+	for _, name := range []string{"pid", "child"} {
+		bin, err := os.ReadFile(filepath.Join(dir, name))
+		assert.Nil(t, err)
+		pid, err := strconv.Atoi(strings.TrimSpace(string(bin)))
+		assert.Nil(t, err)
+		assert.True(t, terminated(pid), "process %s (%d) is still running", name, pid)
+	}
+}
+
+// terminated returns true when the process is gone. A zombie waiting to
+// be reaped by its new parent counts as terminated.
+func terminated(pid int) bool {
+	if syscall.Kill(pid, 0) != nil {
+		return true
+	}
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	return err == nil && strings.Contains(string(stat), ") Z ")
+}
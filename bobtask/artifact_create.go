@@ -23,6 +23,7 @@ import (
 const __targetsFilesystem = "targets/filesystem"
 const __targetsDocker = "targets/docker"
 const __metadata = "__metadata"
+const __output = "__output"
 
 var ErrInvalidTarHeaderType = fmt.Errorf("invalid tar header type")
 
@@ -178,6 +179,20 @@ func (t *Task) ArtifactCreate(artifactName hash.In) (err error) {
 	})
 	errz.Fatal(err)
 
+	// captured output of the task, replayed on cache hits
+	output, err := t.output(artifactName)
+	errz.Fatal(err)
+	if output != nil {
+		err = archiveWriter.Write(archiver.File{
+			FileInfo: fileInfo{
+				name: __output,
+				data: output,
+			},
+			ReadCloser: io.NopCloser(bytes.NewBuffer(output)),
+		})
+		errz.Fatal(err)
+	}
+
 	return nil
 }
 
@@ -127,6 +127,15 @@ func (t *Task) ArtifactExtract(artifactName hash.In, invalidFiles map[string][]t
 			defer func() { _ = os.Remove(dst) }()
 		}
 
+		// captured output, restored to the log store
+		if header.Name == __output && t.logStore != nil {
+			f, err := t.logStore.Create(t.name, artifactName.String())
+			errz.Fatal(err)
+			_, err = io.Copy(f, archiveFile)
+			_ = f.Close()
+			errz.Fatal(err)
+		}
+
 	}
 
 	return true, nil
@@ -7,6 +7,7 @@ import (
 
 	"github.com/benchkram/bob/pkg/buildinfostore"
 	"github.com/benchkram/bob/pkg/file"
+	"github.com/benchkram/bob/pkg/logstore"
 	"github.com/benchkram/bob/pkg/store/filestore"
 	"github.com/benchkram/errz"
 	"github.com/stretchr/testify/assert"
@@ -64,3 +65,49 @@ func TestArtifactCreateAndExtract(t *testing.T) {
 	_, err = tsk.ArtifactInspect("aaa")
 	assert.Nil(t, err)
 }
+
+func TestArtifactOutput(t *testing.T) {
+	testdir, err := os.MkdirTemp("", "test-artifact-output")
+	assert.Nil(t, err)
+	defer os.RemoveAll(testdir)
+
+	assert.Nil(t, os.MkdirAll(filepath.Join(testdir, "project"), 0774))
+	assert.Nil(t, os.MkdirAll(filepath.Join(testdir, "artifacts"), 0774))
+	assert.Nil(t, os.MkdirAll(filepath.Join(testdir, "buildinfo"), 0774))
+	assert.Nil(t, os.WriteFile(filepath.Join(testdir, "project", "target"), []byte("target"), 0774))
+
+	tsk := Make()
+	tsk.dir = filepath.Join(testdir, "project")
+	tsk.local = filestore.New(filepath.Join(testdir, "artifacts"))
+	tsk.buildInfoStore = buildinfostore.NewProtoStore(filepath.Join(testdir, "buildinfo"))
+	tsk.name = "mytaskname"
+	tsk.logStore = logstore.New(filepath.Join(testdir, "logs"))
+
+	tsk.TargetDirty = "target"
+	err = tsk.parseTargets()
+	assert.Nil(t, err)
+
+	f, err := tsk.logStore.Create(tsk.name, "aaa")
+	assert.Nil(t, err)
+	_, err = f.Write([]byte("warning: unused variable\n"))
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+
+	err = tsk.ArtifactCreate("aaa")
+	assert.Nil(t, err)
+
+	output, err := tsk.artifactOutput("aaa")
+	assert.Nil(t, err)
+	assert.Equal(t, "warning: unused variable\n", string(output))
+
+	// extracting the artifact restores the output in the log store
+	assert.Nil(t, os.RemoveAll(filepath.Join(testdir, "logs")))
+
+	success, err := tsk.ArtifactExtract("aaa", nil)
+	assert.Nil(t, err)
+	assert.True(t, success)
+
+	output, err = tsk.output("aaa")
+	assert.Nil(t, err)
+	assert.Equal(t, "warning: unused variable\n", string(output))
+}
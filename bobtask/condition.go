@@ -0,0 +1,50 @@
+package bobtask
+
+import (
+	"runtime"
+	"strings"
+)
+
+// Condition restricts a task to a platform or environment.
+// A task is enabled when all of the given conditions are met.
+type Condition struct {
+	// OS must match the operating system, e.g. `linux` or `darwin`.
+	OS string `yaml:"os,omitempty"`
+	// Arch must match the architecture, e.g. `amd64` or `arm64`.
+	Arch string `yaml:"arch,omitempty"`
+	// Env is either `KEY=value` requiring the variable to equal value
+	// or `KEY` requiring the variable to be set to a non-empty value.
+	Env string `yaml:"env,omitempty"`
+}
+
+// Met evaluates the condition on the current platform
+// using env in the "key=value" format.
+func (c *Condition) Met(env []string) bool {
+	if c.OS != "" && c.OS != runtime.GOOS {
+		return false
+	}
+	if c.Arch != "" && c.Arch != runtime.GOARCH {
+		return false
+	}
+	if c.Env != "" {
+		key, want, hasValue := strings.Cut(c.Env, "=")
+		value := lookupEnv(env, key)
+		if hasValue && value != want {
+			return false
+		}
+		if !hasValue && value == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// lookupEnv returns the value of the last occurrence of key in env.
+func lookupEnv(env []string, key string) (value string) {
+	for _, v := range env {
+		if k, val, ok := strings.Cut(v, "="); ok && k == key {
+			value = val
+		}
+	}
+	return value
+}
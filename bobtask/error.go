@@ -9,6 +9,13 @@ var (
 	ErrHashInDoesNotExist     = fmt.Errorf("input-hash does not exist")
 	ErrInvalidInput           = fmt.Errorf("invalid input")
 	ErrBuildinfostoreIsNil    = fmt.Errorf("buildinfostore is nil")
+	ErrInvalidTimeout         = fmt.Errorf("invalid timeout")
+	ErrInvalidRetries         = fmt.Errorf("invalid retries")
+	ErrInvalidRetryDelay      = fmt.Errorf("invalid retry_delay")
+	ErrInvalidResources       = fmt.Errorf("invalid resources")
+	ErrInvalidEnv             = fmt.Errorf("invalid env")
+	ErrInvalidEnvFile         = fmt.Errorf("invalid env_file")
+	ErrTaskTimedOut           = fmt.Errorf("timed out")
 
 	ErrInvalidTargetDefinition  = fmt.Errorf("invalid target definition, can't find 'path' or 'image' directive")
 	ErrAmbigousTargetDefinition = fmt.Errorf("ambigous target definition, can't have 'path' and 'image' directive on same target")
@@ -0,0 +1,73 @@
+package bobtask
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sync"
+	"time"
+
+	"mvdan.cc/sh/expand"
+	"mvdan.cc/sh/interp"
+
+	"github.com/benchkram/bob/pkg/procgroup"
+)
+
+// DefaultKillTimeout is the time a canceled task is given
+// to terminate before it is killed.
+const DefaultKillTimeout = procgroup.DefaultKillTimeout
+
+// execModule runs programs in their own process group. On cancellation
+// the whole group is terminated, see procgroup.Terminate(). This assures child
+// processes spawned by a program are terminated as well. Running programs
+// are tracked in procs, to be able to wait for background jobs to terminate.
+func execModule(killTimeout time.Duration, procs *sync.WaitGroup) interp.ModuleExec {
+	return func(ctx context.Context, path string, args []string) error {
+		mc, _ := interp.FromModuleContext(ctx)
+		if path == "" {
+			fmt.Fprintf(mc.Stderr, "%q: executable file not found in $PATH\n", args[0])
+			return interp.ExitStatus(127)
+		}
+
+		cmd := &exec.Cmd{
+			Path:   path,
+			Args:   args,
+			Env:    execEnv(mc),
+			Dir:    mc.Dir,
+			Stdin:  mc.Stdin,
+			Stdout: mc.Stdout,
+			Stderr: mc.Stderr,
+		}
+
+		err := procgroup.Start(cmd)
+		if err != nil {
+			fmt.Fprintf(mc.Stderr, "%v\n", err)
+			return interp.ExitStatus(127)
+		}
+
+		err = procgroup.Wait(ctx, cmd, killTimeout, procs)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			if code := exitErr.ExitCode(); code > 0 {
+				return interp.ExitStatus(code)
+			}
+			return interp.ExitStatus(1)
+		}
+		return err
+	}
+}
+
+// execEnv returns the exported variables of the runner's environment.
+func execEnv(mc interp.ModuleCtx) []string {
+	env := []string{}
+	mc.Env.Each(func(name string, vr expand.Variable) bool {
+		if vr.Exported {
+			env = append(env, name+"="+vr.String())
+		}
+		return true
+	})
+	return env
+}
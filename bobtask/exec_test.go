@@ -0,0 +1,136 @@
+//go:build unix
+
+package bobtask
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"mvdan.cc/sh/interp"
+	"mvdan.cc/sh/syntax"
+)
+
+func TestExecModuleKillsProcessGroup(t *testing.T) {
+	dir, err := os.MkdirTemp("", "test-exec-module")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	pidFile := filepath.Join(dir, "pid")
+
+	// the child ignores SIGTERM and must be killed after the timeout
+	script := `sh -c 'trap "" TERM; echo $$ > ` + pidFile + `; sleep 60'`
+	p, err := syntax.NewParser().Parse(strings.NewReader(script), "")
+	assert.Nil(t, err)
+
+	var procs sync.WaitGroup
+	r, err := interp.New(
+		interp.Dir(dir),
+		interp.Module(execModule(100*time.Millisecond, &procs)),
+	)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		// wait for the child to start
+		for {
+			if bin, err := os.ReadFile(pidFile); err == nil && len(bin) > 0 {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		cancel()
+	}()
+
+	done := make(chan error)
+	go func() { done <- r.Run(ctx, p) }()
+
+	select {
+	case err = <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(10 * time.Second):
+		t.Fatal("canceled command did not terminate")
+	}
+	procs.Wait()
+
+	bin, err := os.ReadFile(pidFile)
+	assert.Nil(t, err)
+	pid, err := strconv.Atoi(strings.TrimSpace(string(bin)))
+	assert.Nil(t, err)
+	assert.ErrorIs(t, syscall.Kill(pid, 0), syscall.ESRCH)
+}
+
+func TestTaskRunKillsChildProcesses(t *testing.T) {
+	dir := t.TempDir()
+
+	// the shell and its child ignore SIGTERM and stdin,
+	// they must be killed after the kill timeout
+	task := newRunnableTask(t, dir, Task{
+		CmdDirty: `sh -c 'trap "" TERM; sleep 60 < /dev/null & echo $! > child; echo $$ > pid; wait'`,
+	})
+	task.SetKillTimeout(300 * time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		// wait for the child to start
+		for {
+			if bin, err := os.ReadFile(filepath.Join(dir, "pid")); err == nil && len(bin) > 0 {
+				break
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		cancel()
+	}()
+
+	done := make(chan error)
+	go func() { done <- task.Run(ctx, 0, 1) }()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(10 * time.Second):
+		t.Fatal("canceled task did not terminate")
+	}
+
+	for _, name := range []string{"pid", "child"} {
+		bin, err := os.ReadFile(filepath.Join(dir, name))
+		assert.Nil(t, err)
+		pid, err := strconv.Atoi(strings.TrimSpace(string(bin)))
+		assert.Nil(t, err)
+		assert.True(t, terminated(pid), "process %s (%d) is still running", name, pid)
+	}
+}
+
+func TestTaskRunTimeout(t *testing.T) {
+	task := newRunnableTask(t, t.TempDir(), Task{
+		CmdDirty:     "sleep 60",
+		TimeoutDirty: "200ms",
+	})
+	task.SetKillTimeout(300 * time.Millisecond)
+
+	start := time.Now()
+	err := task.Run(context.Background(), 0, 1)
+	assert.ErrorIs(t, err, ErrTaskTimedOut)
+	assert.Less(t, time.Since(start), 10*time.Second)
+
+	tm := Map{"task": Task{name: "task", TimeoutDirty: "-1s"}}
+	assert.ErrorIs(t, tm.Sanitize(), ErrInvalidTimeout)
+}
+
+// terminated returns true when the process is gone. A zombie waiting to
+// be reaped by its new parent counts as terminated.
+func terminated(pid int) bool {
+	if syscall.Kill(pid, 0) != nil {
+		return true
+	}
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	return err == nil && strings.Contains(string(stat), ") Z ")
+}
@@ -58,3 +58,14 @@ func (t *Task) computeInputHash() (taskHash hash.In, err error) {
 
 	return hashIn, nil
 }
+
+// SetHashIn sets the input hash reused by HashIn().
+func (t *Task) SetHashIn(h hash.In) {
+	t.hashIn = &h
+}
+
+// ResetHashIn drops the stored input hash,
+// it's recomputed on the next call to HashIn().
+func (t *Task) ResetHashIn() {
+	t.hashIn = nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/benchkram/bob/pkg/boberror"
 	"github.com/benchkram/bob/pkg/multilinecmd"
 	"github.com/benchkram/bob/pkg/nix"
+	"github.com/benchkram/bob/pkg/shell"
 	"github.com/benchkram/bob/pkg/usererror"
 )
 
@@ -119,6 +120,36 @@ func (tm Map) Sanitize() (err error) {
 		task.cmds = multilinecmd.Split(task.CmdDirty)
 		task.rebuild = task.sanitizeRebuild(task.RebuildDirty)
 
+		task.timeout, err = task.sanitizeTimeout(task.TimeoutDirty)
+		if err != nil {
+			return usererror.Wrap(err)
+		}
+
+		task.retries, err = task.sanitizeRetries(task.RetriesDirty)
+		if err != nil {
+			return usererror.Wrap(err)
+		}
+
+		task.retryDelay, err = task.sanitizeRetryDelay(task.RetryDelayDirty)
+		if err != nil {
+			return usererror.Wrap(err)
+		}
+
+		task.resources, err = task.sanitizeResources(task.ResourcesDirty)
+		if err != nil {
+			return usererror.Wrap(err)
+		}
+
+		task.shell, err = shell.Parse(task.ShellDirty)
+		if err != nil {
+			return usererror.Wrap(fmt.Errorf("%w [task:%s]", err, task.name))
+		}
+
+		task.taskEnv, err = task.sanitizeEnv(task.EnvDirty, task.EnvFileDirty)
+		if err != nil {
+			return usererror.Wrap(err)
+		}
+
 		tm[key] = task
 	}
 
@@ -0,0 +1,172 @@
+package bobtask
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrInvalidMatrix    = fmt.Errorf("invalid matrix")
+	ErrMatrixTaskExists = fmt.Errorf("matrix task already exists")
+)
+
+// Matrix maps variable names to a list of values. The order of the
+// variables is kept as defined in the Bobfile to name generated tasks.
+type Matrix struct {
+	keys   []string
+	values map[string][]string
+}
+
+func (m *Matrix) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.MappingNode {
+		return fmt.Errorf("%w near line %d: must be a map of value lists", ErrInvalidMatrix, value.Line)
+	}
+
+	m.keys = []string{}
+	m.values = make(map[string][]string)
+	for i := 0; i+1 < len(value.Content); i += 2 {
+		key := value.Content[i].Value
+
+		var values []string
+		err := value.Content[i+1].Decode(&values)
+		if err != nil {
+			return fmt.Errorf("%w near line %d: values of %q must be a list", ErrInvalidMatrix, value.Content[i+1].Line, key)
+		}
+		if len(values) == 0 {
+			return fmt.Errorf("%w near line %d: values of %q must not be empty", ErrInvalidMatrix, value.Content[i+1].Line, key)
+		}
+
+		m.keys = append(m.keys, key)
+		m.values[key] = values
+	}
+
+	return nil
+}
+
+func (m Matrix) MarshalYAML() (interface{}, error) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	for _, key := range m.keys {
+		values := &yaml.Node{}
+		err := values.Encode(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		values.Style = yaml.FlowStyle
+		node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, values)
+	}
+	return node, nil
+}
+
+// combinations returns every combination of the matrix values,
+// each as a list of values in the order of the keys.
+func (m *Matrix) combinations() [][]string {
+	combinations := [][]string{{}}
+	for _, key := range m.keys {
+		next := [][]string{}
+		for _, c := range combinations {
+			for _, v := range m.values[key] {
+				combination := append(append([]string{}, c...), v)
+				next = append(next, combination)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// ExpandMatrices replaces each task with a matrix by one task per
+// combination of the matrix values, e.g. `build[linux-amd64]`. The
+// values are set as env vars and replace `${KEY}` in input, target,
+// dependsOn, dependencies and env of the generated tasks. The original
+// task depends on all generated tasks, so building it builds all of them.
+func (tm Map) ExpandMatrices() error {
+	// collect first to not alter the map while ranging over it
+	names := []string{}
+	for name, task := range tm {
+		if task.Matrix != nil {
+			names = append(names, name)
+		}
+	}
+
+	for _, name := range names {
+		task := tm[name]
+
+		parent := Make()
+		generated := []string{}
+		for _, values := range task.Matrix.combinations() {
+			genName := fmt.Sprintf("%s[%s]", name, strings.Join(values, "-"))
+			if _, exists := tm[genName]; exists {
+				return fmt.Errorf("%w: %s", ErrMatrixTaskExists, genName)
+			}
+
+			vars := make(map[string]string, len(values))
+			for i, key := range task.Matrix.keys {
+				vars[key] = values[i]
+			}
+
+			tm[genName] = withMatrixValues(task, vars)
+			generated = append(generated, genName)
+		}
+
+		parent.DependsOn = generated
+		tm[name] = parent
+	}
+
+	return nil
+}
+
+// withMatrixValues returns a copy of the task without a matrix,
+// parameterised by vars.
+func withMatrixValues(t Task, vars map[string]string) Task {
+	expand := func(s string) string {
+		for key, value := range vars {
+			s = strings.ReplaceAll(s, "${"+key+"}", value)
+		}
+		return s
+	}
+
+	expandSlice := func(s []string) []string {
+		if s == nil {
+			return nil
+		}
+		expanded := make([]string, 0, len(s))
+		for _, v := range s {
+			expanded = append(expanded, expand(v))
+		}
+		return expanded
+	}
+
+	t.Matrix = nil
+	t.DependsOn = expandSlice(t.DependsOn)
+	t.DependenciesDirty = expandSlice(t.DependenciesDirty)
+
+	env := make(map[string]string, len(t.EnvDirty)+len(vars))
+	for key, value := range t.EnvDirty {
+		env[key] = expand(value)
+	}
+	for key, value := range vars {
+		env[key] = value
+	}
+	t.EnvDirty = env
+
+	t.InputDirty = expand(t.InputDirty)
+
+	switch target := t.TargetDirty.(type) {
+	case string:
+		t.TargetDirty = expand(target)
+	case map[string]interface{}:
+		expanded := make(map[string]interface{}, len(target))
+		for key, value := range target {
+			if s, ok := value.(string); ok {
+				expanded[key] = expand(s)
+			} else {
+				expanded[key] = value
+			}
+		}
+		t.TargetDirty = expanded
+	}
+
+	return t
+}
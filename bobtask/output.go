@@ -0,0 +1,109 @@
+package bobtask
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+
+	"github.com/benchkram/errz"
+	"github.com/logrusorgru/aurora"
+
+	"github.com/benchkram/bob/bobtask/hash"
+	"github.com/benchkram/bob/pkg/boblog"
+	"github.com/benchkram/bob/pkg/logstore"
+)
+
+// ReplayOutput prints the output captured when the task was run
+// for its current input hash. Falls back to the output stored in
+// the artifact when the log store has none.
+func (t *Task) ReplayOutput(namePad int) (err error) {
+	defer errz.Recover(&err)
+
+	hashIn, err := t.HashIn()
+	errz.Fatal(err)
+
+	output, err := t.output(hashIn)
+	errz.Fatal(err)
+
+	if output == nil {
+		output, err = t.artifactOutput(hashIn)
+		errz.Fatal(err)
+	}
+
+	s := bufio.NewScanner(bytes.NewReader(output))
+	for s.Scan() {
+		boblog.Log.V(1).Info(fmt.Sprintf("%-*s\t  %s", namePad, t.ColoredName(), aurora.Faint(s.Text())))
+	}
+
+	return s.Err()
+}
+
+// output returns the output captured in the log store for the given
+// input hash, nil if there is none.
+func (t *Task) output(hashIn hash.In) ([]byte, error) {
+	if t.logStore == nil {
+		return nil, nil
+	}
+
+	path, err := t.logStore.Path(t.name, hashIn.String())
+	if err != nil {
+		if errors.Is(err, logstore.ErrLogNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return os.ReadFile(path)
+}
+
+// artifactOutput returns the output stored in the artifact of the
+// given input hash, nil if the artifact or the output does not exist.
+func (t *Task) artifactOutput(artifactName hash.In) (_ []byte, err error) {
+	defer errz.Recover(&err)
+
+	if t.local == nil {
+		return nil, nil
+	}
+
+	artifact, _, err := t.local.GetArtifact(context.TODO(), artifactName.String())
+	if err != nil {
+		_, ok := err.(*fs.PathError)
+		if ok {
+			return nil, nil
+		}
+		errz.Fatal(err)
+	}
+	defer artifact.Close()
+
+	archiveReader := newArchiveReader()
+	err = archiveReader.Open(artifact, 0)
+	errz.Fatal(err)
+	defer archiveReader.Close()
+
+	for {
+		archiveFile, err := archiveReader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			errz.Fatal(err)
+		}
+
+		header, ok := archiveFile.Header.(*tar.Header)
+		if !ok {
+			return nil, ErrInvalidTarHeaderType
+		}
+
+		if header.Name == __output {
+			return io.ReadAll(archiveFile)
+		}
+	}
+
+	return nil, nil
+}
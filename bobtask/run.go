@@ -3,12 +3,16 @@ package bobtask
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"sync"
 
 	"github.com/benchkram/bob/pkg/boblog"
 	"github.com/benchkram/bob/pkg/envutil"
+	"github.com/benchkram/bob/pkg/procgroup"
 	"github.com/benchkram/bob/pkg/usererror"
 	"github.com/logrusorgru/aurora"
 	"mvdan.cc/sh/expand"
@@ -18,7 +22,10 @@ import (
 	"github.com/benchkram/errz"
 )
 
-func (t *Task) Run(ctx context.Context, namePad int) (err error) {
+// Run executes the commands of the task. attempt counts the runs of the
+// task starting at 1, retries append their output to the log of the first
+// attempt.
+func (t *Task) Run(ctx context.Context, namePad int, attempt int) (err error) {
 	defer errz.Recover(&err)
 
 	nixEnv, ok := t.envStore[t.envID]
@@ -28,10 +35,50 @@ func (t *Task) Run(ctx context.Context, namePad int) (err error) {
 
 	env := envutil.Merge(nixEnv, t.env)
 
+	if t.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+		defer cancel()
+	}
+
+	// On cancellation wait for all programs, including
+	// background jobs, to be terminated.
+	var procs sync.WaitGroup
+	defer func() {
+		if ctx.Err() != nil {
+			procs.Wait()
+		}
+	}()
+
+	// Capture the output of the task in the log store.
+	logWriter := io.Discard
+	if t.logStore != nil {
+		hashIn, err := t.HashIn()
+		errz.Fatal(err)
+
+		var f io.WriteCloser
+		if attempt <= 1 {
+			f, err = t.logStore.Create(t.name, hashIn.String())
+			errz.Fatal(err)
+		} else {
+			f, err = t.logStore.Append(t.name, hashIn.String())
+			errz.Fatal(err)
+			_, err = fmt.Fprintf(f, "--- attempt %d ---\n", attempt)
+			errz.Fatal(err)
+		}
+		defer f.Close()
+		logWriter = f
+	}
+
 	for _, run := range t.cmds {
-		p, err := syntax.NewParser().Parse(strings.NewReader(run), "")
-		if err != nil {
-			return usererror.Wrapm(err, "shell command parse error")
+		// commands are parsed by the embedded interpreter
+		// unless the task uses an external shell.
+		var p *syntax.File
+		if t.shell == nil {
+			p, err = syntax.NewParser().Parse(strings.NewReader(run), "")
+			if err != nil {
+				return usererror.Wrapm(err, "shell command parse error")
+			}
 		}
 
 		pr, pw, err := os.Pipe()
@@ -52,24 +99,36 @@ func (t *Task) Run(ctx context.Context, namePad int) (err error) {
 				}
 
 				boblog.Log.V(1).Info(fmt.Sprintf("%-*s\t  %s", namePad, t.ColoredName(), aurora.Faint(s.Text())))
+				_, _ = fmt.Fprintln(logWriter, s.Text())
 			}
 
 			done <- true
 		}()
 
-		r, err := interp.New(
-			interp.Params("-e"),
-			interp.Dir(t.dir),
-			interp.Env(expand.ListEnviron(env...)),
-			interp.StdIO(os.Stdin, pw, pw),
-		)
-
-		errz.Fatal(err)
-
-		err = r.Run(ctx, p)
+		if t.shell != nil {
+			err = t.runShell(ctx, run, env, pw, &procs)
+		} else {
+			var r *interp.Runner
+			r, err = interp.New(
+				interp.Params("-e"),
+				interp.Dir(t.dir),
+				interp.Env(expand.ListEnviron(env...)),
+				interp.StdIO(os.Stdin, pw, pw),
+				interp.Module(execModule(t.KillTimeout(), &procs)),
+			)
+			errz.Fatal(err)
+
+			err = r.Run(ctx, p)
+		}
 		if err != nil {
 			pw.Close()
 			<-done
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return usererror.Wrap(fmt.Errorf("task `%s` %w after %s", t.name, ErrTaskTimedOut, t.timeout))
+			}
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return usererror.Wrap(fmt.Errorf("task `%s` %w", t.name, context.Canceled))
+			}
 			return usererror.Wrapm(err, "shell command execute error")
 		}
 
@@ -81,3 +140,21 @@ func (t *Task) Run(ctx context.Context, namePad int) (err error) {
 
 	return nil
 }
+
+// runShell runs a command line with the external shell of the task.
+func (t *Task) runShell(ctx context.Context, cmdline string, env []string, out io.Writer, procs *sync.WaitGroup) error {
+	cmd, err := t.shell.Command(cmdline, t.dir, env)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	err = procgroup.Start(cmd)
+	if err != nil {
+		return err
+	}
+
+	return procgroup.Wait(ctx, cmd, t.KillTimeout(), procs)
+}
@@ -2,7 +2,12 @@ package bobtask
 
 import (
 	"fmt"
+	"path/filepath"
+	"sort"
 	"strings"
+	"time"
+
+	"github.com/benchkram/bob/pkg/envutil"
 )
 
 // sanitizeInputs assures that inputs are only cosidered when they are inside the project dir.
@@ -31,3 +36,120 @@ func (t *Task) sanitizeRebuild(s string) RebuildType {
 		return RebuildOnChange
 	}
 }
+
+// sanitizeTimeout used to transform from dirty member to internal member.
+// An empty string disables the timeout.
+func (t *Task) sanitizeTimeout(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q [task:%s]: %s", ErrInvalidTimeout, s, t.name, err)
+	}
+	if timeout < 0 {
+		return 0, fmt.Errorf("%w %q [task:%s]: must be positive", ErrInvalidTimeout, s, t.name)
+	}
+
+	return timeout, nil
+}
+
+// sanitizeRetries assures the number of retries is not negative.
+func (t *Task) sanitizeRetries(retries int) (int, error) {
+	if retries < 0 {
+		return 0, fmt.Errorf("%w %d [task:%s]: must be positive", ErrInvalidRetries, retries, t.name)
+	}
+	return retries, nil
+}
+
+// sanitizeRetryDelay used to transform from dirty member to internal member.
+// An empty string means retrying immediately.
+func (t *Task) sanitizeRetryDelay(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	delay, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q [task:%s]: %s", ErrInvalidRetryDelay, s, t.name, err)
+	}
+	if delay < 0 {
+		return 0, fmt.Errorf("%w %q [task:%s]: must be positive", ErrInvalidRetryDelay, s, t.name)
+	}
+
+	return delay, nil
+}
+
+// sanitizeResources used to transform from dirty member to internal member.
+// A list of resource names occupies one unit of each resource.
+func (t *Task) sanitizeResources(dirty interface{}) (map[string]int, error) {
+	resources := make(map[string]int)
+
+	switch r := dirty.(type) {
+	case nil:
+	case []interface{}:
+		for _, name := range r {
+			n, ok := name.(string)
+			if !ok || n == "" {
+				return nil, fmt.Errorf("%w [task:%s]: resource names must be strings", ErrInvalidResources, t.name)
+			}
+			resources[n]++
+		}
+	case map[string]interface{}:
+		for name, amount := range r {
+			a, ok := amount.(int)
+			if !ok || a < 1 {
+				return nil, fmt.Errorf("%w [task:%s]: amount of %q must be a positive integer", ErrInvalidResources, t.name, name)
+			}
+			resources[name] = a
+		}
+	default:
+		return nil, fmt.Errorf("%w [task:%s]: must be a list or a map of amounts", ErrInvalidResources, t.name)
+	}
+
+	return resources, nil
+}
+
+// sanitizeEnv used to transform from dirty members to internal member.
+// Env files are read relative to the task's directory in the given order,
+// variables defined in env take precedence.
+func (t *Task) sanitizeEnv(env map[string]string, envFileDirty interface{}) ([]string, error) {
+	var envFiles []string
+	switch f := envFileDirty.(type) {
+	case nil:
+	case string:
+		envFiles = append(envFiles, f)
+	case []interface{}:
+		for _, path := range f {
+			p, ok := path.(string)
+			if !ok || p == "" {
+				return nil, fmt.Errorf("%w [task:%s]: paths must be strings", ErrInvalidEnvFile, t.name)
+			}
+			envFiles = append(envFiles, p)
+		}
+	default:
+		return nil, fmt.Errorf("%w [task:%s]: must be a path or a list of paths", ErrInvalidEnvFile, t.name)
+	}
+
+	result := []string{}
+	for _, f := range envFiles {
+		vars, err := envutil.ReadFile(filepath.Join(t.dir, f))
+		if err != nil {
+			return nil, fmt.Errorf("%w [task:%s]: %s", ErrInvalidEnvFile, t.name, err)
+		}
+		result = envutil.Merge(result, vars)
+	}
+
+	vars := make([]string, 0, len(env))
+	for key, value := range env {
+		if key == "" || strings.Contains(key, "=") {
+			return nil, fmt.Errorf("%w [task:%s]: invalid variable name %q", ErrInvalidEnv, t.name, key)
+		}
+		vars = append(vars, key+"="+value)
+	}
+	result = envutil.Merge(result, vars)
+
+	sort.Strings(result)
+	return result, nil
+}
@@ -163,6 +163,7 @@ func (t *Task) UnmarshalYAML(value *yaml.Node) (err error) {
 	errz.Fatal(err)
 
 	tmpTask.DependsOn = dependsOn
+	tmpTask.node = value
 
 	*t = Task(tmpTask)
 
@@ -3,15 +3,19 @@ package bobtask
 import (
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/benchkram/bob/pkg/envutil"
 	"github.com/benchkram/bob/pkg/nix"
+	"github.com/benchkram/bob/pkg/shell"
 	"github.com/logrusorgru/aurora"
+	"gopkg.in/yaml.v3"
 
 	"github.com/benchkram/bob/bobtask/hash"
 	"github.com/benchkram/bob/bobtask/target"
 	"github.com/benchkram/bob/pkg/buildinfostore"
 	"github.com/benchkram/bob/pkg/dockermobyutil"
+	"github.com/benchkram/bob/pkg/logstore"
 	"github.com/benchkram/bob/pkg/store"
 )
 
@@ -55,13 +59,67 @@ type Task struct {
 	RebuildDirty string `yaml:"rebuild,omitempty"`
 	rebuild      RebuildType
 
+	// TimeoutDirty is the maximum execution time of the task, e.g. `5m`.
+	TimeoutDirty string `yaml:"timeout,omitempty"`
+	// timeout is the parsed TimeoutDirty. A zero value disables the timeout.
+	timeout time.Duration
+
+	// killTimeout is the time the task is given to terminate
+	// after it has been canceled, before it is killed.
+	killTimeout time.Duration
+
+	// RetriesDirty is the number of times a failed task is retried.
+	RetriesDirty int `yaml:"retries,omitempty"`
+	retries      int
+
+	// RetryDelayDirty is the time to wait between retries, e.g. `5s`.
+	RetryDelayDirty string `yaml:"retry_delay,omitempty"`
+	retryDelay      time.Duration
+
+	// ResourcesDirty are the resources occupied while the task is running.
+	// Either a list of names `[db]` or a map of amounts `{memory: 4}`.
+	ResourcesDirty interface{} `yaml:"resources,omitempty"`
+	// resources maps a resource name to the occupied amount.
+	resources map[string]int
+
+	// Extends is the name of a template the task inherits its fields from.
+	// See Map.Extend().
+	Extends string `yaml:"extends,omitempty"`
+	// node is the definition of the task read from a bobfile.
+	node *yaml.Node
+
+	// Matrix expands the task into one task per combination of its values.
+	// See Map.ExpandMatrices().
+	Matrix *Matrix `yaml:"matrix,omitempty"`
+
+	// When is a condition which must be met for the task to run.
+	// Otherwise the task is disabled.
+	When *Condition `yaml:"when,omitempty"`
+	// disabled tasks are a no-op satisfying their dependents.
+	disabled bool
+
+	// ShellDirty is an external shell executing the commands of the task,
+	// e.g. `bash` or `[python3, -c]`. Defaults to the embedded interpreter.
+	ShellDirty interface{} `yaml:"shell,omitempty"`
+	shell      shell.Shell
+
+	// EnvDirty are environment variables set for the task.
+	EnvDirty map[string]string `yaml:"env,omitempty"`
+	// EnvFileDirty are dotenv files read into the environment of the task.
+	// Either a single path or a list of paths.
+	EnvFileDirty interface{} `yaml:"env_file,omitempty"`
+	// taskEnv holds key=value pairs of EnvFileDirty and EnvDirty.
+	// Variables of EnvDirty take precedence.
+	taskEnv []string
+
 	// name is the name of the task
 	name string
 
 	// taskID is a integer provided to
 	// avoid referencing tasks by name
 	// (string comparison, map access)
-	TaskID int
+	// Not exposed in a Bobfile.
+	TaskID int `yaml:"-"`
 
 	// project this tasks belongs to
 	project string
@@ -94,6 +152,9 @@ type Task struct {
 	// buildInfoStore stores buildinfos.
 	buildInfoStore buildinfostore.Store
 
+	// logStore keeps the output of the task.
+	logStore *logstore.Store
+
 	// color is used to color the task's name on the terminal
 	color aurora.Color
 
@@ -180,6 +241,36 @@ func (t *Task) IsValidDecoration() bool {
 	if t.RebuildDirty != "" {
 		return false
 	}
+	if t.TimeoutDirty != "" {
+		return false
+	}
+	if t.RetriesDirty != 0 {
+		return false
+	}
+	if t.RetryDelayDirty != "" {
+		return false
+	}
+	if t.ResourcesDirty != nil {
+		return false
+	}
+	if t.Extends != "" {
+		return false
+	}
+	if t.Matrix != nil {
+		return false
+	}
+	if t.When != nil {
+		return false
+	}
+	if t.ShellDirty != nil {
+		return false
+	}
+	if len(t.EnvDirty) > 0 {
+		return false
+	}
+	if t.EnvFileDirty != nil {
+		return false
+	}
 	if len(t.DependenciesDirty) > 0 {
 		return false
 	}
@@ -204,11 +295,13 @@ func (t *Task) description() string {
 	for _, v := range t.cmds {
 		sb.WriteString(v)
 	}
+	sb.WriteString(t.shell.String())
 
 	sb.WriteString(t.project)
 	sb.WriteString(t.nixpkgs)
 
-	// env is influenced by t.dependencies, so no need to hash t.dependencies
+	// env is influenced by t.dependencies, so no need to hash t.dependencies.
+	// It includes the task's own env and env_file variables.
 	sort.Strings(t.env)
 	for _, v := range t.env {
 		// ignore buildCommandPath and SHLVL due to non-reproducibility
@@ -2,11 +2,14 @@ package bobtask
 
 import (
 	"path/filepath"
+	"time"
 
 	"github.com/benchkram/bob/pkg/buildinfostore"
 	"github.com/benchkram/bob/pkg/dockermobyutil"
 	"github.com/benchkram/bob/pkg/envutil"
+	"github.com/benchkram/bob/pkg/logstore"
 	"github.com/benchkram/bob/pkg/nix"
+	"github.com/benchkram/bob/pkg/shell"
 	"github.com/benchkram/bob/pkg/store"
 	"github.com/logrusorgru/aurora"
 )
@@ -40,6 +43,42 @@ func (t *Task) Rebuild() RebuildType {
 	return t.rebuild
 }
 
+// Timeout returns the maximum execution time of the task.
+// Zero means no timeout.
+func (t *Task) Timeout() time.Duration {
+	return t.timeout
+}
+
+func (t *Task) SetTimeout(timeout time.Duration) {
+	t.timeout = timeout
+}
+
+// KillTimeout returns the time the task is given to terminate
+// after it has been canceled. Zero kills it right away.
+func (t *Task) KillTimeout() time.Duration {
+	return t.killTimeout
+}
+
+func (t *Task) SetKillTimeout(timeout time.Duration) {
+	t.killTimeout = timeout
+}
+
+// Retries returns how often a failed task is retried.
+func (t *Task) Retries() int {
+	return t.retries
+}
+
+// RetryDelay returns the time to wait between retries.
+func (t *Task) RetryDelay() time.Duration {
+	return t.retryDelay
+}
+
+// Resources returns the amount of each resource
+// occupied while the task is running.
+func (t *Task) Resources() map[string]int {
+	return t.resources
+}
+
 func (t *Task) SetDir(dir string) {
 	t.dir = dir
 }
@@ -48,6 +87,26 @@ func (t *Task) SetName(name string) {
 	t.name = name
 }
 
+// Disabled returns true when the task's condition is not met.
+func (t *Task) Disabled() bool {
+	return t.disabled
+}
+
+func (t *Task) SetDisabled(disabled bool) {
+	t.disabled = disabled
+}
+
+// Shell returns the external shell of the task,
+// nil in case the embedded interpreter is used.
+func (t *Task) Shell() shell.Shell {
+	return t.shell
+}
+
+// TaskEnv returns the variables defined by the task's env and env_file.
+func (t *Task) TaskEnv() []string {
+	return t.taskEnv
+}
+
 func (t *Task) SetEnv(env []string) {
 	t.env = env
 }
@@ -111,6 +170,11 @@ func (t *Task) WithDockerRegistryClient(c dockermobyutil.RegistryClient) *Task {
 	return t
 }
 
+func (t *Task) WithLogStore(s *logstore.Store) *Task {
+	t.logStore = s
+	return t
+}
+
 func (t *Task) WithEnvStore(s envutil.Store) *Task {
 	t.envStore = s
 	return t
@@ -1,11 +1,20 @@
 package bobtask
 
 import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/benchkram/bob/bobtask/hash"
+	"github.com/benchkram/bob/pkg/envutil"
 )
 
 var withLowercase = `
@@ -35,6 +44,19 @@ dependson:
       - database
 `
 
+// newRunnableTask returns the task sanitized and ready to run in dir,
+// programs are looked up in the PATH of the test.
+func newRunnableTask(t *testing.T, dir string, task Task) Task {
+	task.SetName("task")
+	task.SetDir(dir)
+	tm := Map{"task": task}
+	assert.Nil(t, tm.Sanitize())
+
+	task = tm["task"]
+	task.WithEnvStore(envutil.Store{"": []string{"PATH=" + os.Getenv("PATH")}})
+	return task
+}
+
 func TestTaskUnmarshalYAMLDependsOn(t *testing.T) {
 	type test struct {
 		input string
@@ -63,3 +85,225 @@ func TestTaskUnmarshalYAMLWithBothDependsOn(t *testing.T) {
 	err := yaml.Unmarshal([]byte(withBoth), &task)
 	assert.EqualError(t, err, "both `dependson` and `dependsOn` nodes detected near line 2")
 }
+
+func TestTaskSanitizeRetries(t *testing.T) {
+	tm := Map{"task": Task{name: "task", RetriesDirty: 3, RetryDelayDirty: "2s"}}
+	err := tm.Sanitize()
+	assert.Nil(t, err)
+
+	task := tm["task"]
+	assert.Equal(t, 3, task.Retries())
+	assert.Equal(t, 2*time.Second, task.RetryDelay())
+
+	tm = Map{"task": Task{name: "task", RetriesDirty: -1}}
+	err = tm.Sanitize()
+	assert.ErrorIs(t, err, ErrInvalidRetries)
+
+	tm = Map{"task": Task{name: "task", RetryDelayDirty: "soon"}}
+	err = tm.Sanitize()
+	assert.ErrorIs(t, err, ErrInvalidRetryDelay)
+}
+
+func TestTaskSanitizeInvalidResources(t *testing.T) {
+	tm := Map{"task": Task{name: "task", ResourcesDirty: map[string]interface{}{"memory": 0}}}
+	err := tm.Sanitize()
+	assert.ErrorIs(t, err, ErrInvalidResources)
+
+	tm = Map{"task": Task{name: "task", ResourcesDirty: "db"}}
+	err = tm.Sanitize()
+	assert.ErrorIs(t, err, ErrInvalidResources)
+}
+
+func TestTaskEnv(t *testing.T) {
+	dir := t.TempDir()
+	envFile := filepath.Join(dir, ".env")
+	assert.Nil(t, os.WriteFile(envFile, []byte("FOO=file\nBAR=file\n"), 0664))
+
+	newTask := func(foo string) Task {
+		task := newRunnableTask(t, dir, Task{
+			CmdDirty:     `echo "$FOO $BAR" > out`,
+			EnvDirty:     map[string]string{"FOO": foo},
+			EnvFileDirty: ".env",
+		})
+		// as done when aggregating the bobfiles
+		task.SetEnv(task.TaskEnv())
+		return task
+	}
+
+	// env takes precedence over env_file
+	task := newTask("env")
+	assert.Nil(t, task.Run(context.Background(), 0, 1))
+	out, err := os.ReadFile(filepath.Join(dir, "out"))
+	assert.Nil(t, err)
+	assert.Equal(t, "env file\n", string(out))
+
+	hashIn, err := task.HashIn()
+	assert.Nil(t, err)
+
+	assert.Nil(t, os.WriteFile(envFile, []byte("FOO=file\nBAR=changed\n"), 0664))
+	task = newTask("env")
+	changed, err := task.HashIn()
+	assert.Nil(t, err)
+	assert.NotEqual(t, hashIn, changed, "a changed env_file must change the input hash")
+
+	task = newTask("changed")
+	changed, err = task.HashIn()
+	assert.Nil(t, err)
+	assert.NotEqual(t, hashIn, changed, "a changed env must change the input hash")
+
+	tm := Map{"task": Task{name: "task", dir: dir, EnvFileDirty: []interface{}{"missing.env"}}}
+	err = tm.Sanitize()
+	assert.ErrorIs(t, err, ErrInvalidEnvFile)
+
+	tm = Map{"task": Task{name: "task", dir: dir, EnvDirty: map[string]string{"A=B": "c"}}}
+	err = tm.Sanitize()
+	assert.ErrorIs(t, err, ErrInvalidEnv)
+}
+
+func TestTaskShell(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("bash is not installed")
+	}
+
+	dir := t.TempDir()
+	newTask := func(shell interface{}) Task {
+		task := newRunnableTask(t, dir, Task{
+			CmdDirty:   `echo "${BASH_VERSION:+bash} $FOO $(pwd)" > out`,
+			EnvDirty:   map[string]string{"FOO": "env"},
+			ShellDirty: shell,
+		})
+		// as done when aggregating the bobfiles
+		task.SetEnv(task.TaskEnv())
+		return task
+	}
+
+	task := newTask("bash")
+	assert.Nil(t, task.Run(context.Background(), 0, 1))
+	out, err := os.ReadFile(filepath.Join(dir, "out"))
+	assert.Nil(t, err)
+	assert.Equal(t, "bash env "+dir+"\n", string(out))
+
+	hashes := map[hash.In]interface{}{}
+	for _, shell := range []interface{}{nil, "bash", "sh", []interface{}{"bash", "-c"}} {
+		task := newTask(shell)
+		hashIn, err := task.HashIn()
+		assert.Nil(t, err)
+		assert.NotContains(t, hashes, hashIn, "shell %v has the same input hash as %v", shell, hashes[hashIn])
+		hashes[hashIn] = shell
+	}
+}
+
+func TestConditionMet(t *testing.T) {
+	env := []string{"CI=true", "EMPTY="}
+
+	tests := []struct {
+		condition Condition
+		met       bool
+	}{
+		{Condition{}, true},
+		{Condition{OS: runtime.GOOS}, true},
+		{Condition{OS: "plan9"}, false},
+		{Condition{OS: runtime.GOOS, Arch: runtime.GOARCH}, true},
+		{Condition{Arch: "sparc"}, false},
+		{Condition{Env: "CI=true"}, true},
+		{Condition{Env: "CI=false"}, false},
+		{Condition{Env: "CI"}, true},
+		{Condition{Env: "EMPTY"}, false},
+		{Condition{Env: "MISSING"}, false},
+		{Condition{OS: runtime.GOOS, Env: "MISSING"}, false},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.met, tc.condition.Met(env), "%+v", tc.condition)
+	}
+}
+
+var withMatrix = `
+matrix:
+  GOOS: [linux, darwin]
+  GOARCH: [amd64, arm64]
+input: cmd/${GOOS}
+cmd: go build -o bin/${GOOS}-${GOARCH}/app
+target: bin/${GOOS}-${GOARCH}/app
+dependsOn:
+  - generate
+`
+
+func TestExpandMatrices(t *testing.T) {
+	var task Task
+	err := yaml.Unmarshal([]byte(withMatrix), &task)
+	assert.Nil(t, err)
+
+	tm := Map{"build-bin": task}
+	err = tm.ExpandMatrices()
+	assert.Nil(t, err)
+
+	assert.Len(t, tm, 5)
+	assert.Equal(t, []string{
+		"build-bin[linux-amd64]",
+		"build-bin[linux-arm64]",
+		"build-bin[darwin-amd64]",
+		"build-bin[darwin-arm64]",
+	}, tm["build-bin"].DependsOn)
+
+	gen := tm["build-bin[darwin-arm64]"]
+	assert.Nil(t, gen.Matrix)
+	assert.Equal(t, "cmd/darwin", gen.InputDirty)
+	assert.Equal(t, "bin/darwin-arm64/app", gen.TargetDirty)
+	assert.Equal(t, map[string]string{"GOOS": "darwin", "GOARCH": "arm64"}, gen.EnvDirty)
+	assert.Equal(t, []string{"generate"}, gen.DependsOn)
+
+	// generated names must not collide with existing tasks
+	tm = Map{"build-bin": task, "build-bin[linux-amd64]": Task{}}
+	err = tm.ExpandMatrices()
+	assert.ErrorIs(t, err, ErrMatrixTaskExists)
+
+	err = yaml.Unmarshal([]byte("matrix:\n  GOOS: []\n"), &task)
+	assert.ErrorIs(t, err, ErrInvalidMatrix)
+}
+
+var withTemplates = `
+go-build:
+  input: "*.go"
+  cmd: go build -o app
+  target: app
+go-build-race:
+  extends: go-build
+  cmd: go build -race -o app
+`
+
+func TestExtend(t *testing.T) {
+	var templates Templates
+	err := yaml.Unmarshal([]byte(withTemplates), &templates)
+	assert.Nil(t, err)
+
+	var tm Map
+	err = yaml.Unmarshal([]byte(`
+build:
+  extends: go-build-race
+  target: bin/app
+`), &tm)
+	assert.Nil(t, err)
+
+	err = tm.Extend(templates)
+	assert.Nil(t, err)
+
+	task := tm["build"]
+	assert.Equal(t, "", task.Extends)
+	assert.Equal(t, "*.go", task.InputDirty)
+	assert.Equal(t, "go build -race -o app", task.CmdDirty)
+	assert.Equal(t, "bin/app", task.TargetDirty)
+
+	err = yaml.Unmarshal([]byte("build:\n  extends: missing\n"), &tm)
+	assert.Nil(t, err)
+	err = tm.Extend(templates)
+	assert.ErrorIs(t, err, ErrTemplateNotFound)
+
+	templates["a"] = yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+		{Kind: yaml.ScalarNode, Value: "extends"}, {Kind: yaml.ScalarNode, Value: "a"},
+	}}
+	err = yaml.Unmarshal([]byte("build:\n  extends: a\n"), &tm)
+	assert.Nil(t, err)
+	err = tm.Extend(templates)
+	assert.ErrorIs(t, err, ErrTemplateCycle)
+}
@@ -0,0 +1,111 @@
+package bobtask
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrTemplateNotFound = fmt.Errorf("template not found")
+	ErrTemplateCycle    = fmt.Errorf("template cycle detected")
+	ErrInvalidTemplate  = fmt.Errorf("invalid template")
+)
+
+const extendsKey = "extends"
+
+// Templates maps template names to task definitions.
+type Templates map[string]yaml.Node
+
+// Extend replaces each task extending a template by the merge of the
+// template and the task. Fields defined on the task override the ones
+// of the template. Templates can extend other templates.
+func (tm Map) Extend(templates Templates) error {
+	for name, task := range tm {
+		if task.Extends == "" {
+			continue
+		}
+		if task.node == nil {
+			return fmt.Errorf("%w [task:%s]: task must be read from a bobfile", ErrInvalidTemplate, name)
+		}
+
+		base, err := templates.resolve(task.Extends, []string{})
+		if err != nil {
+			return fmt.Errorf("%w [task:%s]", err, name)
+		}
+
+		var extended Task
+		err = merge(base, task.node).Decode(&extended)
+		if err != nil {
+			return fmt.Errorf("%w [task:%s]: %s", ErrInvalidTemplate, name, err)
+		}
+
+		tm[name] = extended
+	}
+
+	return nil
+}
+
+// resolve returns the definition of a template including
+// the definitions of the templates it extends.
+func (ts Templates) resolve(name string, stack []string) (*yaml.Node, error) {
+	for _, s := range stack {
+		if s == name {
+			return nil, fmt.Errorf("%w: %s", ErrTemplateCycle, strings.Join(append(stack, name), " -> "))
+		}
+	}
+
+	template, ok := ts[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+	}
+	node := &template
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%w: %s must be a map", ErrInvalidTemplate, name)
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != extendsKey {
+			continue
+		}
+		base, err := ts.resolve(node.Content[i+1].Value, append(stack, name))
+		if err != nil {
+			return nil, err
+		}
+		return merge(base, node), nil
+	}
+
+	return node, nil
+}
+
+// merge returns a mapping node with the keys of base and override,
+// keys of override replace the ones of base. The `extends` key is dropped.
+func merge(base, override *yaml.Node) *yaml.Node {
+	merged := &yaml.Node{
+		Kind: yaml.MappingNode,
+		Tag:  override.Tag,
+		Line: override.Line,
+	}
+
+	overridden := map[string]bool{}
+	for i := 0; i+1 < len(override.Content); i += 2 {
+		overridden[override.Content[i].Value] = true
+	}
+
+	for i := 0; i+1 < len(base.Content); i += 2 {
+		key := base.Content[i].Value
+		if key == extendsKey || overridden[key] {
+			continue
+		}
+		merged.Content = append(merged.Content, base.Content[i], base.Content[i+1])
+	}
+	for i := 0; i+1 < len(override.Content); i += 2 {
+		if override.Content[i].Value == extendsKey {
+			continue
+		}
+		merged.Content = append(merged.Content, override.Content[i], override.Content[i+1])
+	}
+
+	return merged
+}
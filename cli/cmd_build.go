@@ -6,18 +6,27 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"slices"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/benchkram/errz"
+	"github.com/logrusorgru/aurora"
 	"github.com/spf13/cobra"
 
 	"github.com/benchkram/bob/bob"
 	"github.com/benchkram/bob/bob/global"
+	"github.com/benchkram/bob/bob/playbook"
 	"github.com/benchkram/bob/pkg/boblog"
 	"github.com/benchkram/bob/pkg/usererror"
 )
 
+const (
+	outputText  = "text"
+	outputJSONL = "jsonl"
+)
+
 var buildCmd = &cobra.Command{
 	Use:   "build",
 	Short: "Run tasks",
@@ -46,12 +55,63 @@ var buildCmd = &cobra.Command{
 		noPull, err := cmd.Flags().GetBool("no-pull")
 		errz.Fatal(err)
 
-		taskname := global.DefaultBuildTask
+		keepGoing, err := cmd.Flags().GetBool("keep-going")
+		errz.Fatal(err)
+
+		taskTimeout, err := cmd.Flags().GetDuration("task-timeout")
+		errz.Fatal(err)
+		if taskTimeout < 0 {
+			boblog.Log.UserError(fmt.Errorf("task-timeout must not be negative"))
+			os.Exit(1)
+		}
+
+		killTimeout, err := cmd.Flags().GetDuration("kill-timeout")
+		errz.Fatal(err)
+		if killTimeout < 0 {
+			boblog.Log.UserError(fmt.Errorf("kill-timeout must not be negative"))
+			os.Exit(1)
+		}
+
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		errz.Fatal(err)
+
+		explain, err := cmd.Flags().GetBool("explain")
+		errz.Fatal(err)
+
+		tasknames := []string{global.DefaultBuildTask}
 		if len(args) > 0 {
-			taskname = args[0]
+			tasknames = args
+		}
+
+		output, err := cmd.Flags().GetString("output")
+		errz.Fatal(err)
+		if output != outputText && output != outputJSONL {
+			boblog.Log.UserError(fmt.Errorf("invalid output format %q, use one of: %s, %s", output, outputText, outputJSONL))
+			os.Exit(1)
 		}
 
-		runBuild(taskname, noCache, allowInsecure, enablePush, noPull, flagEnvVars, maxParallel)
+		outputFile, err := cmd.Flags().GetString("output-file")
+		errz.Fatal(err)
+		if outputFile != "" && output != outputJSONL {
+			boblog.Log.UserError(fmt.Errorf("--output-file requires --output=%s", outputJSONL))
+			os.Exit(1)
+		}
+
+		traceFile, err := cmd.Flags().GetString("trace")
+		errz.Fatal(err)
+
+		watch, err := cmd.Flags().GetBool("watch")
+		errz.Fatal(err)
+
+		replayOutput, err := cmd.Flags().GetBool("replay-output")
+		errz.Fatal(err)
+
+		if dryRun || explain {
+			runExplain(tasknames, noCache, allowInsecure, noPull, flagEnvVars)
+			return
+		}
+
+		runBuild(tasknames, noCache, allowInsecure, enablePush, noPull, keepGoing, watch, replayOutput, flagEnvVars, maxParallel, taskTimeout, killTimeout, output, outputFile, traceFile)
 	},
 	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		tasks, err := getBuildTasks()
@@ -72,13 +132,32 @@ var buildListCmd = &cobra.Command{
 	},
 }
 
-func runBuild(taskname string, noCache, allowInsecure, enablePush, noPull bool, flagEnvVars []string, maxParallel int) {
+func runBuild(tasknames []string, noCache, allowInsecure, enablePush, noPull, keepGoing, watch, replayOutput bool, flagEnvVars []string, maxParallel int, taskTimeout, killTimeout time.Duration, output, outputFile, traceFile string) {
 	var exitCode int
 	defer func() {
 		exit(exitCode)
 	}()
 	defer errz.Recover()
 
+	var eventHandler playbook.EventHandler
+	if output == outputJSONL {
+		w := os.Stdout
+		if outputFile != "" {
+			f, err := os.Create(outputFile)
+			if err != nil {
+				exitCode = 1
+				errz.Fatal(err)
+			}
+			defer f.Close()
+			w = f
+		} else {
+			// stdout is reserved for events,
+			// logs are written to stderr.
+			boblog.SetOutput(os.Stderr)
+		}
+		eventHandler = playbook.JSONLEventHandler(w)
+	}
+
 	b, err := bob.Bob(
 		bob.WithCachingEnabled(!noCache),
 		bob.WithInsecure(allowInsecure),
@@ -86,6 +165,12 @@ func runBuild(taskname string, noCache, allowInsecure, enablePush, noPull bool,
 		bob.WithMaxParallel(maxParallel),
 		bob.WithPushEnabled(enablePush),
 		bob.WithPullEnabled(!noPull),
+		bob.WithKeepGoing(keepGoing),
+		bob.WithReplayOutput(replayOutput),
+		bob.WithTaskTimeout(taskTimeout),
+		bob.WithKillTimeout(killTimeout),
+		bob.WithEventHandler(eventHandler),
+		bob.WithTraceFile(traceFile),
 	)
 	if err != nil {
 		exitCode = 1
@@ -103,7 +188,11 @@ func runBuild(taskname string, noCache, allowInsecure, enablePush, noPull bool,
 		cancel()
 	}()
 
-	err = b.Build(ctx, taskname)
+	if watch {
+		err = b.Watch(ctx, tasknames...)
+	} else {
+		err = b.Build(ctx, tasknames...)
+	}
 	if err != nil {
 		exitCode = 1
 		if errors.As(err, &usererror.Err) {
@@ -114,6 +203,66 @@ func runBuild(taskname string, noCache, allowInsecure, enablePush, noPull bool,
 	}
 }
 
+func runExplain(tasknames []string, noCache, allowInsecure, noPull bool, flagEnvVars []string) {
+	var exitCode int
+	defer func() {
+		exit(exitCode)
+	}()
+	defer errz.Recover()
+
+	b, err := bob.Bob(
+		bob.WithCachingEnabled(!noCache),
+		bob.WithInsecure(allowInsecure),
+		bob.WithEnvVariables(parseEnvVarsFlag(flagEnvVars)),
+		bob.WithPullEnabled(!noPull),
+	)
+	if err != nil {
+		exitCode = 1
+		errz.Fatal(err)
+	}
+
+	explanations, err := b.Explain(context.Background(), tasknames...)
+	if err != nil {
+		exitCode = 1
+		if errors.As(err, &usererror.Err) {
+			boblog.Log.UserError(err)
+			return
+		}
+		errz.Fatal(err)
+	}
+
+	printExplanations(explanations)
+}
+
+// printExplanations prints the rebuild decisions as a table.
+func printExplanations(explanations []*playbook.Explanation) {
+	header := []string{"TASK", "INPUT HASH", "DECISION", "CAUSE", "ARTIFACT", "INVALID TARGETS"}
+
+	rows := [][]string{}
+	for _, e := range explanations {
+		cause := string(e.Cause)
+		if cause == "" {
+			cause = "-"
+		}
+
+		artifact := "-"
+		if e.InLocalStore {
+			artifact = "local"
+		} else if e.InRemoteStore {
+			artifact = "remote"
+		}
+
+		invalidFiles := "-"
+		if len(e.InvalidFiles) > 0 {
+			invalidFiles = strings.Join(e.InvalidFiles, ",")
+		}
+
+		rows = append(rows, []string{e.TaskName, e.InputHash.String(), string(e.Decision), cause, artifact, invalidFiles})
+	}
+
+	printTable(header, rows)
+}
+
 func runBuildList() {
 	b, err := bob.Bob()
 	boblog.Log.Error(err, "Unable to initialize bob")
@@ -121,7 +270,14 @@ func runBuildList() {
 	tasks, err := b.GetBuildTasks()
 	boblog.Log.Error(err, "Unable to aggregate bob file")
 
+	disabled, err := b.GetDisabledBuildTasks()
+	boblog.Log.Error(err, "Unable to aggregate bob file")
+
 	for _, t := range tasks {
+		if slices.Contains(disabled, t) {
+			fmt.Printf("%s %s\n", t, aurora.Faint("(disabled)"))
+			continue
+		}
 		fmt.Println(t)
 	}
 }
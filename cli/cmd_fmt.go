@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/benchkram/errz"
+	"github.com/spf13/cobra"
+
+	"github.com/benchkram/bob/bob"
+	"github.com/benchkram/bob/pkg/boblog"
+	"github.com/benchkram/bob/pkg/usererror"
+)
+
+var fmtCmd = &cobra.Command{
+	Use:   "fmt",
+	Short: "Rewrite bob.yaml files into a canonical form",
+	Long: `Rewrite the bob.yaml and all imported bob.yaml files into a canonical form.
+Keys are brought into a stable order, tasks are sorted by name and
+multiline input, cmd and target values are normalised. Comments are kept.
+
+Example:
+  bob fmt          format all bob.yaml files
+  bob fmt --check  list unformatted files and exit non-zero if there are any
+`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		check, err := cmd.Flags().GetBool("check")
+		errz.Fatal(err)
+
+		ok, err := runFmt(check)
+		if errors.As(err, &usererror.Err) {
+			boblog.Log.UserError(err)
+			os.Exit(1)
+		} else {
+			errz.Fatal(err)
+		}
+		if !ok {
+			os.Exit(1)
+		}
+	},
+}
+
+// runFmt formats all bobfiles, ok is false in case
+// of check and files not being formatted.
+func runFmt(check bool) (ok bool, err error) {
+	defer errz.Recover(&err)
+
+	b, err := bob.Bob()
+	errz.Fatal(err)
+
+	unformatted, err := b.Format(check)
+	errz.Fatal(err)
+
+	for _, f := range unformatted {
+		fmt.Println(f)
+	}
+
+	return !check || len(unformatted) == 0, nil
+}
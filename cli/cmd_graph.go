@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/benchkram/errz"
+	"github.com/spf13/cobra"
+
+	"github.com/benchkram/bob/bob"
+	"github.com/benchkram/bob/pkg/boblog"
+	"github.com/benchkram/bob/pkg/usererror"
+)
+
+const (
+	graphFormatDOT     = "dot"
+	graphFormatMermaid = "mermaid"
+	graphFormatJSON    = "json"
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph [task]",
+	Short: "Print the task graph",
+	Long: `Print the graph of build and run tasks and their dependencies.
+Given a task only the task and the tasks it depends on are printed.
+
+Example:
+  bob graph --format dot | dot -Tsvg > graph.svg
+  bob graph build --format mermaid --details
+  bob graph --format json --status
+`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		format, err := cmd.Flags().GetString("format")
+		errz.Fatal(err)
+		details, err := cmd.Flags().GetBool("details")
+		errz.Fatal(err)
+		status, err := cmd.Flags().GetBool("status")
+		errz.Fatal(err)
+
+		var taskname string
+		if len(args) > 0 {
+			taskname = args[0]
+		}
+
+		err = runGraph(taskname, format, details, status)
+		if errors.As(err, &usererror.Err) {
+			boblog.Log.UserError(err)
+			os.Exit(1)
+		} else {
+			errz.Fatal(err)
+		}
+	},
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		tasks, err := getBuildTasks()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		return tasks, cobra.ShellCompDirectiveDefault
+	},
+}
+
+func runGraph(taskname, format string, details, status bool) (err error) {
+	defer errz.Recover(&err)
+
+	switch format {
+	case graphFormatDOT, graphFormatMermaid, graphFormatJSON:
+	default:
+		return usererror.Wrap(fmt.Errorf("invalid format %q, must be one of: %s, %s, %s", format, graphFormatDOT, graphFormatMermaid, graphFormatJSON))
+	}
+
+	b, err := bob.Bob()
+	errz.Fatal(err)
+
+	g, err := b.Graph(context.Background(), taskname, status)
+	errz.Fatal(err)
+
+	switch format {
+	case graphFormatDOT:
+		fmt.Print(g.DOT(details))
+	case graphFormatMermaid:
+		fmt.Print(g.Mermaid(details))
+	case graphFormatJSON:
+		bin, err := g.JSON()
+		errz.Fatal(err)
+		fmt.Println(string(bin))
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/benchkram/errz"
+	"github.com/spf13/cobra"
+
+	"github.com/benchkram/bob/bob"
+	"github.com/benchkram/bob/pkg/boblog"
+	"github.com/benchkram/bob/pkg/format"
+	"github.com/benchkram/bob/pkg/history"
+	"github.com/benchkram/bob/pkg/usererror"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List past builds",
+	Long: `List past builds
+
+Example:
+  bob history           list recorded builds
+  bob history show 42   show the tasks of a build
+`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		err := runHistoryList()
+		if errors.As(err, &usererror.Err) {
+			boblog.Log.UserError(err)
+			os.Exit(1)
+		} else {
+			errz.Fatal(err)
+		}
+	},
+}
+
+var historyShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show the tasks of a past build",
+	Long:  ``,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			boblog.Log.UserError(fmt.Errorf("invalid id %q", args[0]))
+			os.Exit(1)
+		}
+
+		err = runHistoryShow(id)
+		if errors.As(err, &usererror.Err) {
+			boblog.Log.UserError(err)
+			os.Exit(1)
+		} else {
+			errz.Fatal(err)
+		}
+	},
+}
+
+func runHistoryList() (err error) {
+	defer errz.Recover(&err)
+
+	b, err := bob.Bob()
+	errz.Fatal(err)
+
+	runs, err := b.History()
+	errz.Fatal(err)
+
+	if len(runs) == 0 {
+		boblog.Log.V(1).Info("(empty)")
+		return nil
+	}
+
+	header := []string{"ID", "START", "DURATION", "STATUS", "TASKS", "DIR"}
+	rows := [][]string{}
+	for _, r := range runs {
+		rows = append(rows, []string{
+			strconv.Itoa(r.ID),
+			r.Start.Format("2006-01-02 15:04:05"),
+			format.DisplayDuration(r.Duration),
+			r.Status,
+			strings.Join(r.Roots, ","),
+			r.Dir,
+		})
+	}
+	printTable(header, rows)
+
+	return nil
+}
+
+func runHistoryShow(id int) (err error) {
+	defer errz.Recover(&err)
+
+	b, err := bob.Bob()
+	errz.Fatal(err)
+
+	run, err := b.HistoryRun(id)
+	if errors.Is(err, history.ErrRunNotFound) {
+		return usererror.Wrap(err)
+	}
+	errz.Fatal(err)
+
+	fmt.Printf("Build %d of %s in %s\n", run.ID, strings.Join(run.Roots, ", "), run.Dir)
+	fmt.Printf("Started %s, took %s, %s\n", run.Start.Format("2006-01-02 15:04:05"), format.DisplayDuration(run.Duration), run.Status)
+	if run.Error != "" {
+		fmt.Printf("Error: %s\n", run.Error)
+	}
+	fmt.Println()
+
+	header := []string{"TASK", "STATE", "CAUSE", "INPUT HASH", "DURATION"}
+	rows := [][]string{}
+	for _, t := range run.Tasks {
+		cause := t.Cause
+		if cause == "" {
+			cause = "-"
+		}
+		inputHash := t.InputHash
+		if inputHash == "" {
+			inputHash = "-"
+		}
+		duration := "-"
+		if t.Duration > 0 {
+			duration = format.DisplayDuration(t.Duration)
+		}
+		rows = append(rows, []string{t.Name, t.State, cause, inputHash, duration})
+	}
+	printTable(header, rows)
+
+	return nil
+}
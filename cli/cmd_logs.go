@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"github.com/benchkram/errz"
+	"github.com/spf13/cobra"
+
+	"github.com/benchkram/bob/bob"
+	"github.com/benchkram/bob/pkg/boblog"
+	"github.com/benchkram/bob/pkg/usererror"
+)
+
+var logsCmd = &cobra.Command{
+	Use:   "logs <task>",
+	Short: "Show the output of a build task",
+	Long: `Show the output of a build task
+
+Example:
+  bob logs build                   latest output of task build
+  bob logs build --hash=3d8e2c...  output of task build for the given input hash
+`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		hash, err := cmd.Flags().GetString("hash")
+		errz.Fatal(err)
+
+		err = runLogs(args[0], hash)
+		if errors.As(err, &usererror.Err) {
+			boblog.Log.UserError(err)
+			os.Exit(1)
+		} else {
+			errz.Fatal(err)
+		}
+	},
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		tasks, err := getBuildTasks()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		return tasks, cobra.ShellCompDirectiveDefault
+	},
+}
+
+func runLogs(taskName, hash string) (err error) {
+	defer errz.Recover(&err)
+
+	b, err := bob.Bob()
+	errz.Fatal(err)
+
+	path, err := b.TaskLog(taskName, hash)
+	errz.Fatal(err)
+
+	f, err := os.Open(path)
+	errz.Fatal(err)
+	defer f.Close()
+
+	_, err = io.Copy(os.Stdout, f)
+	errz.Fatal(err)
+
+	return nil
+}
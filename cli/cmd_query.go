@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/benchkram/errz"
+	"github.com/spf13/cobra"
+
+	"github.com/benchkram/bob/bob"
+	"github.com/benchkram/bob/pkg/boblog"
+	"github.com/benchkram/bob/pkg/usererror"
+)
+
+const (
+	queryOutputPlain = "plain"
+	queryOutputJSON  = "json"
+)
+
+var queryCmd = &cobra.Command{
+	Use:   "query <query>",
+	Short: "Query tasks, their dependencies, inputs and targets",
+	Long: `Query tasks, their dependencies, inputs and targets
+
+Queries:
+  deps(task)     tasks the task depends on, directly or indirectly
+  rdeps(task)    tasks depending on the task, directly or indirectly
+  owner(path)    tasks having the file as input or target
+  targets(task)  target paths and images of a build task
+  inputs(task)   input files of a build task
+
+Example:
+  bob query 'rdeps(generate)'
+  git diff --name-only | xargs -I{} bob query 'owner({})'
+  bob query 'inputs(build)' --output json
+`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, err := cmd.Flags().GetString("output")
+		errz.Fatal(err)
+
+		err = runQuery(args[0], output)
+		if errors.As(err, &usererror.Err) {
+			boblog.Log.UserError(err)
+			os.Exit(1)
+		} else {
+			errz.Fatal(err)
+		}
+	},
+}
+
+func runQuery(query, output string) (err error) {
+	defer errz.Recover(&err)
+
+	switch output {
+	case queryOutputPlain, queryOutputJSON:
+	default:
+		return usererror.Wrap(fmt.Errorf("invalid output %q, must be one of: %s, %s", output, queryOutputPlain, queryOutputJSON))
+	}
+
+	b, err := bob.Bob()
+	errz.Fatal(err)
+
+	results, err := b.Query(query)
+	errz.Fatal(err)
+
+	if output == queryOutputJSON {
+		bin, err := json.MarshalIndent(struct {
+			Query   string   `json:"query"`
+			Results []string `json:"results"`
+		}{query, results}, "", "  ")
+		errz.Fatal(err)
+
+		fmt.Println(string(bin))
+		return nil
+	}
+
+	for _, r := range results {
+		fmt.Println(r)
+	}
+
+	return nil
+}
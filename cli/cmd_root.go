@@ -9,6 +9,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/benchkram/bob/bob"
+	"github.com/benchkram/bob/bobtask"
 	"github.com/benchkram/bob/pkg/boblog"
 )
 
@@ -26,6 +27,22 @@ func init() {
 	rootCmd.Flags().Bool("version", false, "Show the CLI's version")
 
 	rootCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(schemaCmd)
+
+	// fmtCmd
+	fmtCmd.Flags().Bool("check", false, "Do not write files, exit non-zero if a file is not formatted")
+	rootCmd.AddCommand(fmtCmd)
+
+	// graphCmd
+	graphCmd.Flags().String("format", graphFormatDOT, "Output format, one of: dot, mermaid, json")
+	graphCmd.Flags().Bool("details", false, "Annotate tasks with input count, target type and nix dependencies")
+	graphCmd.Flags().Bool("status", false, "Annotate build tasks with their current rebuild status")
+	rootCmd.AddCommand(graphCmd)
+
+	// queryCmd
+	queryCmd.Flags().String("output", queryOutputPlain, "Output format, one of: plain, json")
+	rootCmd.AddCommand(queryCmd)
 	rootCmd.AddCommand(installCmd)
 	rootCmd.AddCommand(initCmd)
 
@@ -56,6 +73,16 @@ func init() {
 	buildCmd.Flags().Bool("insecure", false, "Set to true to use http instead of https when accessing a remote artifact store")
 	buildCmd.Flags().Bool("debug", false, "Enable debug output")
 	buildCmd.Flags().IntP("jobs", "j", runtime.NumCPU(), "Maximum number of parallel started jobs")
+	buildCmd.Flags().BoolP("keep-going", "k", false, "Continue building independent tasks after a task failed")
+	buildCmd.Flags().Duration("kill-timeout", bobtask.DefaultKillTimeout, "Time canceled tasks are given to terminate before they are killed")
+	buildCmd.Flags().Duration("task-timeout", 0, "Default timeout for tasks without a timeout, e.g. 10m (0 disables it)")
+	buildCmd.Flags().Bool("dry-run", false, "Explain the rebuild decision of each task without running any task")
+	buildCmd.Flags().Bool("explain", false, "Alias for --dry-run")
+	buildCmd.Flags().String("output", "text", "Output format, one of: text, jsonl")
+	buildCmd.Flags().String("output-file", "", "Write the jsonl build events to a file instead of stdout")
+	buildCmd.Flags().Bool("replay-output", false, "Print the captured output of tasks which are not rebuilt")
+	buildCmd.Flags().Bool("watch", false, "Rebuild whenever inputs of the tasks change")
+	buildCmd.Flags().String("trace", "", "Write a chrome trace event file of the build to the given path")
 	buildCmd.Flags().StringSliceVar(&flagEnvVars, "env", []string{}, "Set environment variables to build task")
 	buildCmd.AddCommand(buildListCmd)
 	rootCmd.AddCommand(buildCmd)
@@ -82,6 +109,14 @@ func init() {
 	cleanCmd.AddCommand(cleanSystemCmd)
 	cleanCmd.AddCommand(cleanAllCmd)
 	rootCmd.AddCommand(cleanCmd)
+
+	// logsCmd
+	logsCmd.Flags().String("hash", "", "Show the output for the given input hash instead of the latest")
+	rootCmd.AddCommand(logsCmd)
+
+	// historyCmd
+	historyCmd.AddCommand(historyShowCmd)
+	rootCmd.AddCommand(historyCmd)
 }
 
 var rootCmd = &cobra.Command{
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/benchkram/errz"
+	"github.com/logrusorgru/aurora"
+	"github.com/spf13/cobra"
+
+	"github.com/benchkram/bob/bob"
+	"github.com/benchkram/bob/pkg/boblog"
+	"github.com/benchkram/bob/pkg/usererror"
+)
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check bob.yaml files for problems",
+	Long: `Check the bob.yaml and all imported bob.yaml files for problems
+like unknown keys, wrong types and dependencies on unknown tasks.
+Problems are reported as file:line:col.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ok, err := runValidate()
+		if errors.As(err, &usererror.Err) {
+			boblog.Log.UserError(err)
+			os.Exit(1)
+		} else {
+			errz.Fatal(err)
+		}
+		if !ok {
+			os.Exit(1)
+		}
+	},
+}
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of bob.yaml",
+	Long: `Print the JSON Schema of bob.yaml, to be used by editors
+
+Example:
+  bob schema > bob.schema.json
+`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		schema, err := bob.Schema()
+		errz.Fatal(err)
+
+		fmt.Println(string(schema))
+	},
+}
+
+// runValidate prints the problems found, ok is false if there are any.
+func runValidate() (ok bool, err error) {
+	defer errz.Recover(&err)
+
+	b, err := bob.Bob()
+	errz.Fatal(err)
+
+	problems, err := b.Validate()
+	errz.Fatal(err)
+
+	for _, p := range problems {
+		fmt.Println(p.String())
+	}
+
+	if len(problems) > 0 {
+		fmt.Printf("\n%s\n", aurora.Red(fmt.Sprintf("%d problem(s) found", len(problems))))
+		return false, nil
+	}
+
+	fmt.Println(aurora.Green("Valid"))
+	return true, nil
+}
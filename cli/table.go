@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// printTable prints rows as left aligned columns below a header.
+func printTable(header []string, rows [][]string) {
+	// compute column width based on the widest cell
+	width := make([]int, len(header))
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			if len(cell) > width[i] {
+				width[i] = len(cell)
+			}
+		}
+	}
+
+	printRow := func(row []string) {
+		cells := []string{}
+		for i, cell := range row {
+			cells = append(cells, fmt.Sprintf("%-*s", width[i], cell))
+		}
+		fmt.Println(strings.TrimRight(strings.Join(cells, "  "), " "))
+	}
+
+	printRow(header)
+	for _, row := range rows {
+		printRow(row)
+	}
+}
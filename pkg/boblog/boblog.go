@@ -7,6 +7,8 @@ import (
 	"errors"
 	"fmt"
 	"github.com/benchkram/bob/pkg/usererror"
+	"io"
+	"os"
 	"unicode"
 
 	"github.com/benchkram/errz"
@@ -17,6 +19,10 @@ var Log = log{level: 0}
 
 var globalLogLevel = 1
 
+// output receives all log messages,
+// os.Stdout is used when nil.
+var output io.Writer
+
 func SetLogLevel(level int) {
 	if level < 0 {
 		level = 0
@@ -24,6 +30,20 @@ func SetLogLevel(level int) {
 	globalLogLevel = level
 }
 
+// SetOutput sets the writer log messages are written to,
+// defaults to stdout.
+func SetOutput(w io.Writer) {
+	output = w
+}
+
+// Output returns the writer log messages are written to.
+func Output() io.Writer {
+	if output == nil {
+		return os.Stdout
+	}
+	return output
+}
+
 type log struct {
 	level int
 }
@@ -41,7 +61,7 @@ func (l log) Info(msg string) {
 	if l.level > globalLogLevel {
 		return
 	}
-	fmt.Println(msg)
+	fmt.Fprintln(Output(), msg)
 }
 
 func (l log) Error(err error, msg string, keysAndValues ...interface{}) {
@@ -51,7 +71,7 @@ func (l log) Error(err error, msg string, keysAndValues ...interface{}) {
 	}
 
 	// Error message will always be logged if exists
-	fmt.Print(aurora.Red(msg + ": "))
+	fmt.Fprint(Output(), aurora.Red(msg+": "))
 
 	// Stack trace will only be logged if globalLogLevel >= 2
 	if globalLogLevel >= 2 {
@@ -66,7 +86,7 @@ func (l log) Error(err error, msg string, keysAndValues ...interface{}) {
 			err = er
 		}
 
-		fmt.Println(aurora.Red(err))
+		fmt.Fprintln(Output(), aurora.Red(err))
 	}
 }
 
@@ -99,5 +119,5 @@ func (l log) UserError(err error) {
 		msg = string(tmp)
 	}
 
-	fmt.Println(aurora.Red(msg))
+	fmt.Fprintln(Output(), aurora.Red(msg))
 }
@@ -2,6 +2,7 @@ package envutil
 
 import (
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -44,3 +45,19 @@ func TestMergeEnv(t *testing.T) {
 		assert.Equal(t, tc.expectedResult, result)
 	}
 }
+
+func TestParse(t *testing.T) {
+	in := `
+# comment
+FOO=bar
+export BAZ = "quoted value"
+EMPTY=
+SINGLE='a=b'
+`
+	env, err := Parse(strings.NewReader(in))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"FOO=bar", "BAZ=quoted value", "EMPTY=", "SINGLE=a=b"}, env)
+
+	_, err = Parse(strings.NewReader("FOO"))
+	assert.NotNil(t, err)
+}
@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/benchkram/bob/pkg/boblog"
 	"github.com/benchkram/bob/pkg/filepathxx"
 	"github.com/logrusorgru/aurora"
 )
@@ -99,7 +100,7 @@ func ListRecursive(inp string, projectRoot string) (all []string, err error) {
 	}
 
 	for i, sErr := range symlinkErrors {
-		fmt.Println(fmt.Sprintf("%s", aurora.Red("Warning: ")) + sErr.Error())
+		boblog.Log.Info(fmt.Sprintf("%s", aurora.Red("Warning: ")) + sErr.Error())
 		if i > 10 {
 			break
 		}
@@ -0,0 +1,37 @@
+package history
+
+import "time"
+
+const (
+	StatusSuccess  = "success"
+	StatusFailed   = "failed"
+	StatusCanceled = "canceled"
+)
+
+// Run is the record of a single playbook run.
+type Run struct {
+	ID int `json:"id"`
+
+	// Dir is the directory bob was invoked in.
+	Dir string `json:"dir"`
+	// Roots are the tasks the playbook was build for.
+	Roots []string `json:"roots"`
+
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+
+	// Status is one of success, failed or canceled.
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+
+	Tasks []Task `json:"tasks"`
+}
+
+// Task is the record of a task processed in a run.
+type Task struct {
+	Name      string        `json:"name"`
+	State     string        `json:"state"`
+	Cause     string        `json:"cause,omitempty"`
+	InputHash string        `json:"inputHash,omitempty"`
+	Duration  time.Duration `json:"duration"`
+}
@@ -0,0 +1,164 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/benchkram/errz"
+)
+
+var ErrRunNotFound = errors.New("run not found")
+
+// DefaultMaxRuns is the number of runs kept by default.
+const DefaultMaxRuns = 100
+
+// Store keeps a bounded number of runs, one json file per run.
+// Oldest runs are removed first.
+type Store struct {
+	dir     string
+	maxRuns int
+}
+
+type Option func(s *Store)
+
+// WithMaxRuns sets the number of runs kept in the store.
+func WithMaxRuns(maxRuns int) Option {
+	return func(s *Store) {
+		s.maxRuns = maxRuns
+	}
+}
+
+// New creates a history store. The caller is responsible to pass an
+// existing directory.
+func New(dir string, opts ...Option) *Store {
+	s := &Store{
+		dir:     dir,
+		maxRuns: DefaultMaxRuns,
+	}
+
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		opt(s)
+	}
+
+	return s
+}
+
+// Add assigns the next free id to a run and stores it. Concurrent
+// builds never get the same id, the file of a run is created exclusively.
+// Runs exceeding the capacity of the store are removed.
+func (s *Store) Add(run *Run) (err error) {
+	defer errz.Recover(&err)
+
+	ids, err := s.ids()
+	errz.Fatal(err)
+
+	run.ID = 1
+	if len(ids) > 0 {
+		run.ID = ids[len(ids)-1] + 1
+	}
+
+	var f *os.File
+	for {
+		f, err = os.OpenFile(s.path(run.ID), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0664)
+		if os.IsExist(err) {
+			// taken by a concurrent build
+			run.ID++
+			continue
+		}
+		errz.Fatal(err)
+		break
+	}
+
+	b, err := json.Marshal(run)
+	if err == nil {
+		_, err = f.Write(b)
+	}
+	if errr := f.Close(); err == nil {
+		err = errr
+	}
+	errz.Fatal(err)
+
+	ids, err = s.ids()
+	errz.Fatal(err)
+	for len(ids) > s.maxRuns {
+		err = os.Remove(s.path(ids[0]))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			errz.Fatal(err)
+		}
+		ids = ids[1:]
+	}
+
+	return nil
+}
+
+// Run returns the run with the given id.
+func (s *Store) Run(id int) (_ *Run, err error) {
+	defer errz.Recover(&err)
+
+	b, err := os.ReadFile(s.path(id))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %d", ErrRunNotFound, id)
+		}
+		errz.Fatal(err)
+	}
+
+	run := &Run{}
+	err = json.Unmarshal(b, run)
+	errz.Fatal(err)
+
+	return run, nil
+}
+
+// Runs returns all runs in the store, oldest first.
+func (s *Store) Runs() (_ []*Run, err error) {
+	defer errz.Recover(&err)
+
+	ids, err := s.ids()
+	errz.Fatal(err)
+
+	runs := make([]*Run, 0, len(ids))
+	for _, id := range ids {
+		run, err := s.Run(id)
+		errz.Fatal(err)
+		runs = append(runs, run)
+	}
+
+	return runs, nil
+}
+
+// ids returns the sorted ids of all stored runs.
+func (s *Store) ids() ([]int, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []int{}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		id, err := strconv.Atoi(strings.TrimSuffix(e.Name(), ".json"))
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	return ids, nil
+}
+
+func (s *Store) path(id int) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%d.json", id))
+}
@@ -0,0 +1,53 @@
+package history
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreBounded(t *testing.T) {
+	s := New(t.TempDir(), WithMaxRuns(2))
+
+	for i := 0; i < 3; i++ {
+		err := s.Add(&Run{Roots: []string{"build"}, Status: StatusSuccess})
+		assert.Nil(t, err)
+	}
+
+	runs, err := s.Runs()
+	assert.Nil(t, err)
+	assert.Len(t, runs, 2)
+	assert.Equal(t, 2, runs[0].ID)
+	assert.Equal(t, 3, runs[1].ID)
+
+	_, err = s.Run(1)
+	assert.ErrorIs(t, err, ErrRunNotFound)
+}
+
+func TestStoreConcurrentAdd(t *testing.T) {
+	s := New(t.TempDir())
+
+	const n = 20
+	runs := make([]*Run, n)
+	var wg sync.WaitGroup
+	for i := range runs {
+		runs[i] = &Run{Roots: []string{"build"}, Status: StatusSuccess}
+		wg.Add(1)
+		go func(run *Run) {
+			defer wg.Done()
+			assert.Nil(t, s.Add(run))
+		}(runs[i])
+	}
+	wg.Wait()
+
+	ids := map[int]bool{}
+	for _, run := range runs {
+		ids[run.ID] = true
+	}
+	assert.Len(t, ids, n)
+
+	stored, err := s.Runs()
+	assert.Nil(t, err)
+	assert.Len(t, stored, n)
+}
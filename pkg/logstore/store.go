@@ -0,0 +1,137 @@
+package logstore
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/benchkram/errz"
+)
+
+var (
+	ErrLogNotFound     = errors.New("log not found")
+	ErrInvalidTaskName = errors.New("invalid task name")
+	ErrInvalidHash     = errors.New("invalid hash")
+)
+
+const logExt = ".log"
+
+// Store keeps the output of tasks,
+// one file per task and input hash.
+type Store struct {
+	dir string
+}
+
+// New creates a log store. The caller is responsible to pass an
+// existing directory.
+func New(dir string) *Store {
+	return &Store{
+		dir: dir,
+	}
+}
+
+// Project returns the store keeping the logs of the project in
+// projectDir. Task names are only unique within a project.
+func (s *Store) Project(projectDir string) *Store {
+	sum := sha256.Sum256([]byte(projectDir))
+	return &Store{
+		dir: filepath.Join(s.dir, fmt.Sprintf("%x", sum[:8])),
+	}
+}
+
+// Create truncates or creates the log of a task for the given input hash.
+func (s *Store) Create(taskName, hash string) (_ io.WriteCloser, err error) {
+	return s.open(taskName, hash, os.O_TRUNC)
+}
+
+// Append opens the log of a task for the given input hash for appending,
+// the log is created if it does not exist.
+func (s *Store) Append(taskName, hash string) (_ io.WriteCloser, err error) {
+	return s.open(taskName, hash, os.O_APPEND)
+}
+
+func (s *Store) open(taskName, hash string, flag int) (_ io.WriteCloser, err error) {
+	defer errz.Recover(&err)
+
+	path, err := s.path(taskName, hash)
+	errz.Fatal(err)
+
+	err = os.MkdirAll(filepath.Dir(path), 0775)
+	errz.Fatal(err)
+
+	return os.OpenFile(path, os.O_WRONLY|os.O_CREATE|flag, 0664)
+}
+
+// Path returns the path to the log of a task for the given input hash.
+func (s *Store) Path(taskName, hash string) (string, error) {
+	path, err := s.path(taskName, hash)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("%w for task `%s` and hash %s", ErrLogNotFound, taskName, hash)
+		}
+		return "", err
+	}
+	return path, nil
+}
+
+// Latest returns the path to the most recently written log of a task.
+func (s *Store) Latest(taskName string) (_ string, err error) {
+	defer errz.Recover(&err)
+
+	dir, err := s.taskDir(taskName)
+	errz.Fatal(err)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("%w for task `%s`", ErrLogNotFound, taskName)
+		}
+		errz.Fatal(err)
+	}
+
+	var latest os.FileInfo
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), logExt) {
+			continue
+		}
+		info, err := e.Info()
+		errz.Fatal(err)
+
+		if latest == nil || info.ModTime().After(latest.ModTime()) {
+			latest = info
+		}
+	}
+
+	if latest == nil {
+		return "", fmt.Errorf("%w for task `%s`", ErrLogNotFound, taskName)
+	}
+
+	return filepath.Join(dir, latest.Name()), nil
+}
+
+// taskDir returns the directory holding the logs of a task. Task names
+// of imported bobfiles contain `/`, they must not lead out of the store.
+func (s *Store) taskDir(taskName string) (string, error) {
+	if !filepath.IsLocal(taskName) || strings.Contains(taskName, "..") {
+		return "", fmt.Errorf("%w `%s`", ErrInvalidTaskName, taskName)
+	}
+	return filepath.Join(s.dir, taskName), nil
+}
+
+func (s *Store) path(taskName, hash string) (string, error) {
+	dir, err := s.taskDir(taskName)
+	if err != nil {
+		return "", err
+	}
+	if hash == "" || strings.ContainsAny(hash, `/\.`) {
+		return "", fmt.Errorf("%w `%s`", ErrInvalidHash, hash)
+	}
+	return filepath.Join(dir, hash+logExt), nil
+}
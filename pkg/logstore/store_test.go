@@ -0,0 +1,95 @@
+package logstore
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreLatest(t *testing.T) {
+	s := New(t.TempDir())
+
+	_, err := s.Latest("sub/build")
+	assert.ErrorIs(t, err, ErrLogNotFound)
+
+	for _, hash := range []string{"aaa", "bbb"} {
+		w, err := s.Create("sub/build", hash)
+		assert.Nil(t, err)
+		_, err = w.Write([]byte(hash))
+		assert.Nil(t, err)
+		assert.Nil(t, w.Close())
+	}
+
+	aaa, err := s.path("sub/build", "aaa")
+	assert.Nil(t, err)
+	bbb, err := s.path("sub/build", "bbb")
+	assert.Nil(t, err)
+
+	// assure distinct modification times
+	past := time.Now().Add(-time.Minute)
+	assert.Nil(t, os.Chtimes(aaa, past, past))
+
+	latest, err := s.Latest("sub/build")
+	assert.Nil(t, err)
+	assert.Equal(t, bbb, latest)
+
+	path, err := s.Path("sub/build", "aaa")
+	assert.Nil(t, err)
+	assert.Equal(t, aaa, path)
+
+	_, err = s.Path("sub/build", "ccc")
+	assert.ErrorIs(t, err, ErrLogNotFound)
+}
+
+func TestStoreAppend(t *testing.T) {
+	s := New(t.TempDir())
+
+	w, err := s.Create("build", "aaa")
+	assert.Nil(t, err)
+	_, err = w.Write([]byte("first\n"))
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+
+	w, err = s.Append("build", "aaa")
+	assert.Nil(t, err)
+	_, err = w.Write([]byte("second\n"))
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+
+	path, err := s.Path("build", "aaa")
+	assert.Nil(t, err)
+	content, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "first\nsecond\n", string(content))
+}
+
+func TestStoreProject(t *testing.T) {
+	s := New(t.TempDir())
+
+	w, err := s.Project("/work/a").Create("build", "aaa")
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+
+	_, err = s.Project("/work/a").Latest("build")
+	assert.Nil(t, err)
+	_, err = s.Project("/work/b").Latest("build")
+	assert.ErrorIs(t, err, ErrLogNotFound)
+}
+
+func TestStoreInvalidNames(t *testing.T) {
+	s := New(t.TempDir())
+
+	for _, name := range []string{"../build", "sub/../../build", "/etc/build", ""} {
+		_, err := s.Create(name, "aaa")
+		assert.ErrorIs(t, err, ErrInvalidTaskName, name)
+		_, err = s.Latest(name)
+		assert.ErrorIs(t, err, ErrInvalidTaskName, name)
+	}
+
+	for _, hash := range []string{"../aaa", "a/b", ""} {
+		_, err := s.Path("build", hash)
+		assert.ErrorIs(t, err, ErrInvalidHash, hash)
+	}
+}
@@ -3,9 +3,11 @@ package nix
 import (
 	"fmt"
 	"time"
+
+	"github.com/benchkram/bob/pkg/boblog"
 )
 
-// buildProgress tracks building of a Nix package and write to std.Out its progress
+// buildProgress tracks building of a Nix package and writes its progress to the log output
 // example output: `go_1_18: ....`
 type buildProgress struct {
 	// packageName is the name of the package being built ex. go_1_18
@@ -30,14 +32,14 @@ func newBuildProgress(pkgName, padding string) *buildProgress {
 	return &bp
 }
 
-// Start will start progress tracking and write to os.Stout a dot after every duration passes
+// Start will start progress tracking and write a dot to the log output after every duration passes
 func (bp *buildProgress) Start(duration time.Duration) {
-	fmt.Printf("%s:%s", bp.packageName, bp.padding)
+	fmt.Fprintf(boblog.Output(), "%s:%s", bp.packageName, bp.padding)
 
 	bp.ticker = time.NewTicker(duration)
 
 	bp.start = time.Now()
-	fmt.Print(".")
+	fmt.Fprint(boblog.Output(), ".")
 
 	go func() {
 		for {
@@ -45,7 +47,7 @@ func (bp *buildProgress) Start(duration time.Duration) {
 			case <-bp.done:
 				return
 			case <-bp.ticker.C:
-				fmt.Print(".")
+				fmt.Fprint(boblog.Output(), ".")
 			}
 		}
 	}()
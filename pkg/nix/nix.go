@@ -57,7 +57,7 @@ func BuildDependencies(deps []Dependency, cache *Cache) (err error) {
 	}
 
 	if len(unsatisfiedDeps) > 0 {
-		fmt.Println("Building nix dependencies. This may take a while...")
+		boblog.Log.Info("Building nix dependencies. This may take a while...")
 	}
 
 	var max int
@@ -84,8 +84,8 @@ func BuildDependencies(deps []Dependency, cache *Cache) (err error) {
 			}
 		}
 
-		fmt.Println()
-		fmt.Printf("%s:%s%s took %s\n", v.Name, padding, br.storePath, format.DisplayDuration(br.duration))
+		boblog.Log.Info("")
+		boblog.Log.Info(fmt.Sprintf("%s:%s%s took %s", v.Name, padding, br.storePath, format.DisplayDuration(br.duration)))
 
 		if cache != nil {
 			key, err := GenerateKey(v)
@@ -96,7 +96,7 @@ func BuildDependencies(deps []Dependency, cache *Cache) (err error) {
 		}
 	}
 	if len(unsatisfiedDeps) > 0 {
-		fmt.Println("Succeeded building nix dependencies")
+		boblog.Log.Info("Succeeded building nix dependencies")
 	}
 
 	return nil
@@ -0,0 +1,34 @@
+// Package procgroup runs commands in their own process group, which allows
+// to terminate a command including all the child processes it spawned.
+package procgroup
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+// DefaultKillTimeout is the time a canceled process
+// group is given to terminate before it is killed.
+const DefaultKillTimeout = 10 * time.Second
+
+// Wait waits for a command started by Start() and terminates its group
+// when the context is canceled, see Terminate(). The termination is tracked
+// in procs, wait for it to make sure no process of the group is left.
+func Wait(ctx context.Context, cmd *exec.Cmd, killTimeout time.Duration, procs *sync.WaitGroup) error {
+	procs.Add(1)
+	exited := make(chan struct{})
+	go func() {
+		defer procs.Done()
+		select {
+		case <-exited:
+		case <-ctx.Done():
+			Terminate(cmd, killTimeout)
+		}
+	}()
+
+	err := cmd.Wait()
+	close(exited)
+	return err
+}
@@ -0,0 +1,20 @@
+//go:build !unix
+
+package procgroup
+
+import (
+	"os/exec"
+	"time"
+)
+
+// Start starts cmd. Process groups are
+// not supported on this platform.
+func Start(cmd *exec.Cmd) error {
+	return cmd.Start()
+}
+
+// Terminate kills the process of cmd right away, there is no graceful
+// termination on this platform. Child processes are not killed.
+func Terminate(cmd *exec.Cmd, _ time.Duration) {
+	_ = cmd.Process.Kill()
+}
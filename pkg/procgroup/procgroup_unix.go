@@ -0,0 +1,38 @@
+//go:build unix
+
+package procgroup
+
+import (
+	"os/exec"
+	"syscall"
+	"time"
+)
+
+// Start starts cmd as leader of a new process group.
+func Start(cmd *exec.Cmd) error {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	return cmd.Start()
+}
+
+// Terminate sends SIGTERM to the process group of cmd and SIGKILL
+// in case the group is still alive after timeout.
+func Terminate(cmd *exec.Cmd, timeout time.Duration) {
+	pgid := cmd.Process.Pid
+
+	// a negative pid signals the whole process group
+	err := syscall.Kill(-pgid, syscall.SIGTERM)
+	if err != nil {
+		// the group is gone already
+		return
+	}
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+		if syscall.Kill(-pgid, 0) != nil {
+			return
+		}
+	}
+
+	_ = syscall.Kill(-pgid, syscall.SIGKILL)
+}
@@ -4,9 +4,10 @@ import (
 	"fmt"
 	"io"
 	"math"
-	"os"
 	"sync"
 	"time"
+
+	"github.com/benchkram/bob/pkg/boblog"
 )
 
 // Progress tracks progress rendering at a certain interval on a new line
@@ -73,7 +74,7 @@ func (p *Progress) render() {
 	currentHuman, currentUnit := humanizeBytes(float64(p.currentBytes))
 	maxHuman, maxUnit := humanizeBytes(float64(p.maxBytes))
 
-	fmt.Fprintf(os.Stdout, "%s %d%% (%s%s/%s%s)\n", p.description, p.currentPercent, currentHuman, currentUnit, maxHuman, maxUnit)
+	fmt.Fprintf(boblog.Output(), "%s %d%% (%s%s/%s%s)\n", p.description, p.currentPercent, currentHuman, currentUnit, maxHuman, maxUnit)
 	p.lastRendered = time.Now()
 	p.lastPercent = p.currentPercent
 }
@@ -0,0 +1,108 @@
+package shell
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+var ErrInvalidShell = errors.New("invalid shell")
+
+// Shell is an external interpreter command lines are passed to as
+// the last argument. A nil Shell denotes the embedded interpreter.
+type Shell []string
+
+// Parse a shell from its Bobfile representation. A string names
+// a POSIX shell, e.g. `bash`, invoked as `bash -e -c <cmd>`. A list
+// is used as is, e.g. `[python3, -c]`.
+func Parse(dirty interface{}) (Shell, error) {
+	switch s := dirty.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if s == "" {
+			return nil, fmt.Errorf("%w: must not be empty", ErrInvalidShell)
+		}
+		return Shell{s, "-e", "-c"}, nil
+	case []interface{}:
+		if len(s) == 0 {
+			return nil, fmt.Errorf("%w: must not be empty", ErrInvalidShell)
+		}
+		shell := make(Shell, 0, len(s))
+		for _, arg := range s {
+			a, ok := arg.(string)
+			if !ok || a == "" {
+				return nil, fmt.Errorf("%w: arguments must be strings", ErrInvalidShell)
+			}
+			shell = append(shell, a)
+		}
+		return shell, nil
+	default:
+		return nil, fmt.Errorf("%w: must be a name or a list of arguments", ErrInvalidShell)
+	}
+}
+
+// String returns the shell in a form suitable for hashing.
+func (s Shell) String() string {
+	return strings.Join(s, " ")
+}
+
+// Command returns the command to run cmdline with the shell.
+// The shell is looked up in the PATH of env.
+func (s Shell) Command(cmdline string, dir string, env []string) (*exec.Cmd, error) {
+	path, err := lookPath(s[0], dir, env)
+	if err != nil {
+		return nil, err
+	}
+
+	args := append([]string{}, s...)
+	args = append(args, cmdline)
+
+	return &exec.Cmd{
+		Path: path,
+		Args: args,
+		Dir:  dir,
+		Env:  env,
+	}, nil
+}
+
+// lookPath searches for an executable in the PATH of env,
+// names containing a separator are resolved relative to dir.
+func lookPath(file string, dir string, env []string) (string, error) {
+	if strings.Contains(file, string(filepath.Separator)) {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(dir, file)
+		}
+		if isExecutable(file) {
+			return file, nil
+		}
+		return "", fmt.Errorf("%w: %q is not executable", ErrInvalidShell, file)
+	}
+
+	var path string
+	for _, v := range env {
+		if strings.HasPrefix(v, "PATH=") {
+			path = strings.TrimPrefix(v, "PATH=")
+		}
+	}
+
+	for _, d := range filepath.SplitList(path) {
+		p := filepath.Join(d, file)
+		if isExecutable(p) {
+			return p, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q not found in $PATH", ErrInvalidShell, file)
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return !info.IsDir() && info.Mode()&0111 != 0
+}
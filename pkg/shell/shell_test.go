@@ -0,0 +1,41 @@
+package shell
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	s, err := Parse(nil)
+	assert.Nil(t, err)
+	assert.Nil(t, s)
+
+	s, err = Parse("bash")
+	assert.Nil(t, err)
+	assert.Equal(t, Shell{"bash", "-e", "-c"}, s)
+
+	s, err = Parse([]interface{}{"python3", "-c"})
+	assert.Nil(t, err)
+	assert.Equal(t, Shell{"python3", "-c"}, s)
+
+	_, err = Parse([]interface{}{})
+	assert.ErrorIs(t, err, ErrInvalidShell)
+
+	_, err = Parse(map[string]interface{}{})
+	assert.ErrorIs(t, err, ErrInvalidShell)
+}
+
+func TestCommand(t *testing.T) {
+	s := Shell{"sh", "-c"}
+	cmd, err := s.Command("echo hello", ".", []string{"PATH=/usr/bin:/bin"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"sh", "-c", "echo hello"}, cmd.Args)
+
+	out, err := cmd.Output()
+	assert.Nil(t, err)
+	assert.Equal(t, "hello\n", string(out))
+
+	_, err = s.Command("echo hello", ".", []string{"PATH="})
+	assert.ErrorIs(t, err, ErrInvalidShell)
+}
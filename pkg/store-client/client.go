@@ -7,10 +7,10 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/textproto"
-	"os"
 	"time"
 
 	"github.com/benchkram/bob/bob/playbook"
+	"github.com/benchkram/bob/pkg/boblog"
 	progress2 "github.com/benchkram/bob/pkg/progress"
 	"github.com/benchkram/errz"
 	"github.com/pkg/errors"
@@ -176,14 +176,14 @@ func progressBar(ctx context.Context, size int64) *progressbar.ProgressBar {
 	description := getDescription(ctx, "description")
 
 	bar := progressbar.NewOptions64(size,
-		progressbar.OptionSetWriter(os.Stdout),
+		progressbar.OptionSetWriter(boblog.Output()),
 		progressbar.OptionSetPredictTime(false),
 		progressbar.OptionShowCount(),
 		progressbar.OptionThrottle(100*time.Millisecond),
 		progressbar.OptionEnableColorCodes(true),
 		progressbar.OptionSetDescription(description),
 		progressbar.OptionOnCompletion(func() {
-			fmt.Fprint(os.Stdout, "\n")
+			fmt.Fprint(boblog.Output(), "\n")
 		}),
 		progressbar.OptionSetRenderBlankState(false),
 		progressbar.OptionSetTheme(
@@ -2,10 +2,15 @@ package buildtest
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/benchkram/bob/bob"
 	"github.com/benchkram/bob/bob/playbook"
+	"github.com/benchkram/bob/pkg/boblog"
 	"github.com/benchkram/bob/pkg/file"
 	"github.com/benchkram/errz"
 
@@ -57,5 +62,61 @@ var _ = Describe("Test bob build", func() {
 			Expect(rebuild.IsRequired).To(BeTrue())
 			Expect(rebuild.Cause).To(Equal(playbook.TaskForcedRebuild))
 		})
+
+		It("builds multiple root tasks in one playbook", func() {
+			ctx := context.Background()
+
+			tasks := []string{bob.BuildAlwaysTargetName, bob.BuildTargetwithdirsTargetName}
+			Expect(b.Build(ctx, tasks...)).NotTo(HaveOccurred())
+
+			aggregate, err := b.Aggregate()
+			Expect(err).NotTo(HaveOccurred())
+			pb, err := aggregate.PlaybookForTasks(tasks)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, taskName := range tasks {
+				Expect(pb.Tasks).To(HaveKey(taskName))
+			}
+			// shared dependencies are part of the playbook only once
+			Expect(pb.TasksOptimized).To(HaveLen(len(pb.Tasks)))
+		})
+
+		It("writes only events to stdout when building with an event handler", func() {
+			// fresh stores to also build the nix dependencies again
+			storageDir, err := os.MkdirTemp("", "bob-test-build-events-*")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(storageDir)
+
+			pr, pw, err := os.Pipe()
+			Expect(err).NotTo(HaveOccurred())
+			stdout := os.Stdout
+			os.Stdout = pw
+			boblog.SetOutput(os.Stderr)
+			defer func() {
+				os.Stdout = stdout
+				boblog.SetOutput(nil)
+			}()
+
+			out := make(chan []byte)
+			go func() {
+				b, _ := io.ReadAll(pr)
+				out <- b
+			}()
+
+			eb, err := bob.BobWithBaseStoreDir(storageDir,
+				bob.WithDir(dir),
+				bob.WithEventHandler(playbook.JSONLEventHandler(os.Stdout)),
+			)
+			Expect(err).NotTo(HaveOccurred())
+			err = eb.Build(context.Background(), bob.BuildTargetwithdirsTargetName)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(pw.Close()).NotTo(HaveOccurred())
+			lines := strings.Split(strings.TrimSpace(string(<-out)), "\n")
+			Expect(lines).NotTo(BeEmpty())
+			for _, line := range lines {
+				Expect(json.Valid([]byte(line))).To(BeTrue(), "not an event: %q", line)
+			}
+		})
 	})
 })
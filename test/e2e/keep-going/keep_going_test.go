@@ -0,0 +1,52 @@
+package keepgoingtest
+
+import (
+	"context"
+
+	"github.com/benchkram/bob/bob"
+	"github.com/benchkram/bob/pkg/file"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Testing keep-going mode", func() {
+	ctx := context.Background()
+
+	When("a task fails", func() {
+		It("should stop the build without keep-going", func() {
+			err := useBobfile("with_failing_task")
+			Expect(err).NotTo(HaveOccurred())
+			defer func() {
+				err = releaseBobfile("with_failing_task")
+				Expect(err).NotTo(HaveOccurred())
+			}()
+
+			b, err := BobSetup(bob.WithMaxParallel(1))
+			Expect(err).NotTo(HaveOccurred())
+
+			err = b.Build(ctx, "build")
+			Expect(err).To(HaveOccurred())
+
+			Expect(file.Exists("independent")).To(BeFalse())
+			Expect(file.Exists("dependent")).To(BeFalse())
+		})
+
+		It("should build independent tasks and skip dependent ones with keep-going", func() {
+			err := useBobfile("with_failing_task")
+			Expect(err).NotTo(HaveOccurred())
+			defer func() {
+				err = releaseBobfile("with_failing_task")
+				Expect(err).NotTo(HaveOccurred())
+			}()
+
+			b, err := BobSetup(bob.WithMaxParallel(1), bob.WithKeepGoing(true))
+			Expect(err).NotTo(HaveOccurred())
+
+			err = b.Build(ctx, "build")
+			Expect(err).To(HaveOccurred())
+
+			Expect(file.Exists("independent")).To(BeTrue())
+			Expect(file.Exists("dependent")).To(BeFalse())
+		})
+	})
+})
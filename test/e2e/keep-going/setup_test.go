@@ -0,0 +1,32 @@
+package keepgoingtest
+
+import (
+	"os"
+
+	"github.com/benchkram/bob/bob"
+	"github.com/benchkram/errz"
+)
+
+func BobSetup(opts ...bob.Option) (_ *bob.B, err error) {
+	defer errz.Recover(&err)
+
+	static := []bob.Option{
+		bob.WithDir(dir),
+		bob.WithFilestore(artifactStore),
+		bob.WithBuildinfoStore(buildInfoStore),
+	}
+	static = append(static, opts...)
+	return bob.Bob(
+		static...,
+	)
+}
+
+// useBobfile sets the right bobfile to be used for test
+func useBobfile(name string) error {
+	return os.Rename(name+".yaml", "bob.yaml")
+}
+
+// releaseBobfile will revert changes done in useBobfile
+func releaseBobfile(name string) error {
+	return os.Rename("bob.yaml", name+".yaml")
+}